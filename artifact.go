@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"tnd/work/generateJavaEntity/artifactSink"
+)
+
+// openSink returns the sink selected by -sink and the function that closes it and
+// the archive file it writes to.
+func openSink() (artifactSink.Sink, func() error, error) {
+	kind := *sinkKind
+	if !*generateFile {
+		kind = "stdout"
+	}
+	var newArchive func(w io.Writer) artifactSink.Sink
+	switch kind {
+	case "dir":
+		root := *outPath
+		if root == "" {
+			root = "generated"
+		}
+		sink := artifactSink.NewDirectory(root)
+		return sink, sink.Close, nil
+	case "stdout":
+		sink := artifactSink.NewStream(os.Stdout)
+		return sink, sink.Close, nil
+	case "zip":
+		newArchive = artifactSink.NewZip
+	case "tar":
+		newArchive = artifactSink.NewTar
+	default:
+		return nil, nil, fmt.Errorf("unknown -sink %q, expected dir, stdout, zip or tar", kind)
+	}
+	fileName := *outPath
+	if fileName == "" {
+		fileName = "generated." + kind
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("os create %v: %w", fileName, err)
+	}
+	sink := newArchive(file)
+	closeSink := func() error {
+		err := sink.Close()
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close %v: %w", fileName, closeErr)
+		}
+		return err
+	}
+	return sink, closeSink, nil
+}
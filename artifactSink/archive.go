@@ -0,0 +1,71 @@
+package artifactSink
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"fmt"
+	"io"
+	"time"
+)
+
+type zipArchive struct {
+	zw  *zip.Writer
+	now time.Time
+}
+
+// NewZip returns a Sink writing the artifacts as the entries of a zip archive to w.
+func NewZip(w io.Writer) Sink {
+	return zipArchive{zw: zip.NewWriter(w), now: time.Now()}
+}
+
+func (z zipArchive) Write(path string, content []byte) error {
+	entry, err := z.zw.CreateHeader(&zip.FileHeader{Name: path, Method: zip.Deflate, Modified: z.now})
+	if err != nil {
+		return fmt.Errorf("zip create %v: %w", path, err)
+	}
+	if _, err := entry.Write(content); err != nil {
+		return fmt.Errorf("zip write %v: %w", path, err)
+	}
+	return nil
+}
+
+func (z zipArchive) Close() error {
+	if err := z.zw.Close(); err != nil {
+		return fmt.Errorf("zip close: %w", err)
+	}
+	return nil
+}
+
+type tarArchive struct {
+	tw  *tar.Writer
+	now time.Time
+}
+
+// NewTar returns a Sink writing the artifacts as the entries of a tar archive to w.
+func NewTar(w io.Writer) Sink {
+	return tarArchive{tw: tar.NewWriter(w), now: time.Now()}
+}
+
+func (t tarArchive) Write(path string, content []byte) error {
+	err := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		ModTime:  t.now,
+	})
+	if err != nil {
+		return fmt.Errorf("tar header %v: %w", path, err)
+	}
+	if _, err := t.tw.Write(content); err != nil {
+		return fmt.Errorf("tar write %v: %w", path, err)
+	}
+	return nil
+}
+
+func (t tarArchive) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return fmt.Errorf("tar close: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package artifactSink
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+type directory struct {
+	root string
+}
+
+// NewDirectory returns a Sink writing the artifacts as files under root.
+func NewDirectory(root string) Sink {
+	return directory{root: root}
+}
+
+func (d directory) Write(path string, content []byte) error {
+	fileName := filepath.Join(d.root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return fmt.Errorf("mkdir %v: %w", filepath.Dir(fileName), err)
+	}
+	if err := ioutil.WriteFile(fileName, content, 0644); err != nil {
+		return fmt.Errorf("write file %v: %w", fileName, err)
+	}
+	return nil
+}
+
+func (d directory) Close() error {
+	return nil
+}
@@ -0,0 +1,52 @@
+// Package artifactSink receives the rendered source files of the generator.
+package artifactSink
+
+import (
+	"fmt"
+	"io"
+)
+
+// Sink stores artifacts by their path relative to the output root, with slashes
+// as separators. Close finishes the output; it doesn't close underlying writers.
+type Sink interface {
+	Write(path string, content []byte) error
+	Close() error
+}
+
+// Memory keeps the artifacts by path, for tests and tools that post-process them.
+type Memory map[string][]byte
+
+func (m Memory) Write(path string, content []byte) error {
+	m[path] = append([]byte(nil), content...)
+	return nil
+}
+
+func (m Memory) Close() error {
+	return nil
+}
+
+// stream writes the artifacts one after the other, each ending with a new line.
+type stream struct {
+	w io.Writer
+}
+
+// NewStream returns a Sink printing the artifacts to w, such as os.Stdout.
+func NewStream(w io.Writer) Sink {
+	return stream{w: w}
+}
+
+func (s stream) Write(path string, content []byte) error {
+	if _, err := s.w.Write(content); err != nil {
+		return fmt.Errorf("write %v: %w", path, err)
+	}
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		if _, err := io.WriteString(s.w, "\n"); err != nil {
+			return fmt.Errorf("write %v: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (s stream) Close() error {
+	return nil
+}
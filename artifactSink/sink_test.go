@@ -0,0 +1,141 @@
+package artifactSink
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var testArtifacts = []struct {
+	path    string
+	content string
+}{
+	{"entity/Dept.java", "package th.go.cgd.ip.io.entity;\n"},
+	{"repository/DeptRepository.java", "package th.go.cgd.ip.io.repository;\n"},
+	{"restservice/DeptDto.java", ""},
+}
+
+// writeTestArtifacts writes testArtifacts to sink and closes it, returning them
+// as Memory holds them.
+func writeTestArtifacts(t *testing.T, sink Sink) Memory {
+	t.Helper()
+	want := Memory{}
+	for _, a := range testArtifacts {
+		if err := sink.Write(a.path, []byte(a.content)); err != nil {
+			t.Fatalf("write %v: %v", a.path, err)
+		}
+		want.Write(a.path, []byte(a.content))
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return want
+}
+
+func TestMemory(t *testing.T) {
+	sink := Memory{}
+	want := writeTestArtifacts(t, sink)
+	if !reflect.DeepEqual(sink, want) {
+		t.Errorf("got %q, want %q", sink, want)
+	}
+	content := []byte("class A {}")
+	sink.Write("entity/A.java", content)
+	content[0] = 'C'
+	if string(sink["entity/A.java"]) != "class A {}" {
+		t.Errorf("Memory keeps the slice of the caller: %q", sink["entity/A.java"])
+	}
+}
+
+func TestStream(t *testing.T) {
+	var buffer bytes.Buffer
+	writeTestArtifacts(t, NewStream(&buffer))
+	want := "package th.go.cgd.ip.io.entity;\npackage th.go.cgd.ip.io.repository;\n"
+	if buffer.String() != want {
+		t.Errorf("got %q, want %q", buffer.String(), want)
+	}
+}
+
+func TestDirectory(t *testing.T) {
+	root, err := ioutil.TempDir("", "artifactSink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	want := writeTestArtifacts(t, NewDirectory(root))
+	got := Memory{}
+	err = filepath.Walk(root, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return err
+		}
+		path, err := filepath.Rel(root, fileName)
+		if err != nil {
+			return err
+		}
+		return got.Write(filepath.ToSlash(path), content)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestZip(t *testing.T) {
+	var buffer bytes.Buffer
+	want := writeTestArtifacts(t, NewZip(&buffer))
+	zr, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Memory{}
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got.Write(file.Name, content)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTar(t *testing.T) {
+	var buffer bytes.Buffer
+	want := writeTestArtifacts(t, NewTar(&buffer))
+	tr := tar.NewReader(&buffer)
+	got := Memory{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got.Write(header.Name, content)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
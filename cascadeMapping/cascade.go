@@ -1,11 +1,125 @@
 package cascadeMapping
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 	"tnd/work/generateJavaEntity/tableDefinition"
 )
 
-func IsCascadeRelation(from tableDefinition.TableIdentity, to tableDefinition.TableIdentity) bool {
-	result := from.Schema == to.Schema && strings.HasPrefix(to.Name, from.Name+"_") && !strings.Contains(to.Name[len(from.Name):], "_FORM")
-	return result
+// Rule makes the relations from a parent table to the child tables it matches cascade.
+// Parent, Child and Exclude are case insensitive regular expressions matched against
+// the whole table name; {parent} in Child and Exclude stands for the parent name.
+// A child matching Exclude is left to the next rule.
+type Rule struct {
+	Parent  string `json:"parent"`
+	Child   string `json:"child"`
+	Exclude string `json:"exclude"`
+	// Cascade lists the CascadeType constants to use, ALL when empty.
+	Cascade       []string `json:"cascade"`
+	OrphanRemoval *bool    `json:"orphanRemoval"`
+	// CrossSchema lets the rule match a child in another schema than its parent.
+	CrossSchema bool `json:"crossSchema"`
+}
+
+// Rules are tried in order, the first matching rule wins.
+type Rules []Rule
+
+// DefaultRules cascade to the tables of the same schema named after their parent,
+// except forms.
+var DefaultRules = Rules{{
+	Child:   `{parent}_.*`,
+	Exclude: `{parent}(_.*)?_FORM.*`,
+}}
+
+var cascadeTypes = map[string]bool{
+	"ALL":     true,
+	"PERSIST": true,
+	"MERGE":   true,
+	"REMOVE":  true,
+	"REFRESH": true,
+	"DETACH":  true,
+}
+
+// Validate checks the patterns and cascade types of the rules.
+func (rules Rules) Validate() error {
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("cascade rule %v: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (r Rule) validate() error {
+	if r.Child == "" {
+		return fmt.Errorf("child is required")
+	}
+	for _, pattern := range []string{r.Parent, r.Child, r.Exclude} {
+		if _, err := namePattern(pattern, "PARENT"); err != nil {
+			return err
+		}
+	}
+	for _, cascadeType := range r.Cascade {
+		if !cascadeTypes[cascadeType] {
+			return fmt.Errorf("unknown cascade type %v", cascadeType)
+		}
+	}
+	return nil
+}
+
+func namePattern(pattern string, parent string) (*regexp.Regexp, error) {
+	pattern = strings.Replace(pattern, "{parent}", regexp.QuoteMeta(parent), -1)
+	return regexp.Compile(`(?i)^(?:` + pattern + `)$`)
+}
+
+func matchName(pattern string, parent string, name string) bool {
+	if pattern == "" {
+		return false
+	}
+	re, err := namePattern(pattern, parent)
+	return err == nil && re.MatchString(name)
+}
+
+func (r Rule) matches(from tableDefinition.TableIdentity, to tableDefinition.TableIdentity) bool {
+	switch {
+	case from == to:
+	case !r.CrossSchema && from.Schema != to.Schema:
+	case r.Parent != "" && !matchName(r.Parent, from.Name, from.Name):
+	case !matchName(r.Child, from.Name, to.Name):
+	case matchName(r.Exclude, from.Name, to.Name):
+	default:
+		return true
+	}
+	return false
+}
+
+// Find returns the rule making the relation from a parent to a child table cascade.
+func (rules Rules) Find(from tableDefinition.TableIdentity, to tableDefinition.TableIdentity) (Rule, bool) {
+	for _, rule := range rules {
+		if rule.matches(from, to) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// CascadeSpec returns the value of the cascade attribute of the relation annotation.
+func (r Rule) CascadeSpec() string {
+	if len(r.Cascade) == 0 {
+		return "CascadeType.ALL"
+	}
+	var types []string
+	for _, cascadeType := range r.Cascade {
+		types = append(types, "CascadeType."+cascadeType)
+	}
+	if len(types) == 1 {
+		return types[0]
+	}
+	return "{" + strings.Join(types, ", ") + "}"
+}
+
+// RemovesOrphans reports whether collections of the rule are mapped with orphanRemoval, the default.
+func (r Rule) RemovesOrphans() bool {
+	return r.OrphanRemoval == nil || *r.OrphanRemoval
 }
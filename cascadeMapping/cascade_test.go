@@ -0,0 +1,94 @@
+package cascadeMapping
+
+import (
+	"strings"
+	"testing"
+	"tnd/work/generateJavaEntity/tableDefinition"
+)
+
+func onldb(name string) tableDefinition.TableIdentity {
+	return tableDefinition.TableIdentity{Schema: "ONLDB", Name: name}
+}
+
+func TestDefaultRules(t *testing.T) {
+	tests := []struct {
+		from, to tableDefinition.TableIdentity
+		cascade  bool
+	}{
+		{onldb("ORDERS"), onldb("ORDERS_ITEM"), true},
+		{onldb("ORDERS"), onldb("orders_item_note"), true},
+		{onldb("ORDERS"), onldb("ORDERS"), false},
+		{onldb("ORDERS"), onldb("ORDER_ITEM"), false},
+		{onldb("ORDERS"), onldb("ORDERS_FORM"), false},
+		{onldb("ORDERS"), onldb("ORDERS_ITEM_FORM_DATA"), false},
+		{onldb("ORDERS"), tableDefinition.TableIdentity{Schema: "ARCHIVE", Name: "ORDERS_ITEM"}, false},
+		// the parent name is quoted in the patterns
+		{onldb("A.B"), onldb("A.B_ITEM"), true},
+		{onldb("A.B"), onldb("AXB_ITEM"), false},
+	}
+	for _, test := range tests {
+		if _, cascade := DefaultRules.Find(test.from, test.to); cascade != test.cascade {
+			t.Errorf("%v to %v: cascade %v, want %v", test.from.Name, test.to.Name, cascade, test.cascade)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	noOrphanRemoval := false
+	rules := Rules{
+		{Parent: "CUSTOMER", Child: "ADDRESS|CONTACT", Cascade: []string{"PERSIST", "MERGE"}, OrphanRemoval: &noOrphanRemoval},
+		{Parent: "ORDERS", Child: "{parent}_.*", Exclude: "{parent}_AUDIT", Cascade: []string{"ALL"}},
+		{Child: "{parent}_.*", Cascade: []string{"REMOVE"}},
+		{Parent: "LEDGER", Child: "POSTING", CrossSchema: true},
+	}
+	if err := rules.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	tests := []struct {
+		from, to       tableDefinition.TableIdentity
+		cascade        bool
+		spec           string
+		removesOrphans bool
+	}{
+		{onldb("CUSTOMER"), onldb("ADDRESS"), true, "{CascadeType.PERSIST, CascadeType.MERGE}", false},
+		{onldb("CUSTOMER"), onldb("CUSTOMER_NOTE"), true, "CascadeType.REMOVE", true},
+		{onldb("ORDERS"), onldb("ORDERS_ITEM"), true, "CascadeType.ALL", true},
+		// excluded by the ORDERS rule, the next rule matches
+		{onldb("ORDERS"), onldb("ORDERS_AUDIT"), true, "CascadeType.REMOVE", true},
+		{onldb("ORDERS"), onldb("ADDRESS"), false, "", false},
+		{onldb("LEDGER"), tableDefinition.TableIdentity{Schema: "ACCOUNTING", Name: "POSTING"}, true, "CascadeType.ALL", true},
+	}
+	for _, test := range tests {
+		rule, cascade := rules.Find(test.from, test.to)
+		if cascade != test.cascade {
+			t.Errorf("%v to %v: cascade %v, want %v", test.from.Name, test.to.Name, cascade, test.cascade)
+			continue
+		}
+		if !cascade {
+			continue
+		}
+		if spec := rule.CascadeSpec(); spec != test.spec {
+			t.Errorf("%v to %v: cascade spec %v, want %v", test.from.Name, test.to.Name, spec, test.spec)
+		}
+		if removesOrphans := rule.RemovesOrphans(); removesOrphans != test.removesOrphans {
+			t.Errorf("%v to %v: removes orphans %v, want %v", test.from.Name, test.to.Name, removesOrphans, test.removesOrphans)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		rules Rules
+		err   string
+	}{
+		{Rules{{Parent: "ORDERS"}}, "cascade rule 1: child is required"},
+		{Rules{{Child: "{parent}_.*"}, {Child: "ITEM_("}}, "cascade rule 2: error parsing regexp"},
+		{Rules{{Child: "ITEM", Exclude: "["}}, "cascade rule 1: error parsing regexp"},
+		{Rules{{Child: "ITEM", Cascade: []string{"ALL", "DELETE"}}}, "cascade rule 1: unknown cascade type DELETE"},
+	}
+	for _, test := range tests {
+		if err := test.rules.Validate(); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%+v: error %v, want %q", test.rules, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package main
+
+// The database/sql drivers of the -driver products. They are registered here, not
+// in schemaSource, so that tools reading snapshots and DDL scripts only don't need
+// cgo and the DB2 CLI headers.
+import (
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/ibmdb/go_ibm_db"
+	_ "github.com/mattn/go-sqlite3"
+	_ "github.com/sijms/go-ora"
+)
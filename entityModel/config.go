@@ -0,0 +1,259 @@
+package entityModel
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"tnd/work/generateJavaEntity/cascadeMapping"
+)
+
+// Config is the project specific generator configuration read from the -config file.
+// A Config built in code is compiled before use, see Compile.
+type Config struct {
+	TypeMappings []TypeMappingRule `json:"typeMappings"`
+	ManyToMany   []ManyToManyRule  `json:"manyToMany"`
+	// Cascade replaces the default cascade rules when present.
+	Cascade cascadeMapping.Rules `json:"cascade"`
+	Tables  NameFilter           `json:"tables"`
+	Columns NameFilter           `json:"columns"`
+
+	compiled bool
+}
+
+// NameFilter selects names by case insensitive patterns, globs such as *_BAK or
+// regular expressions between slashes like -table, see NewNameMatcher. A name is
+// selected when Include is empty or one of its patterns matches, and no Exclude
+// pattern matches. Tables are matched by NAME and SCHEMA.NAME, columns by NAME and
+// TABLE.NAME.
+type NameFilter struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+
+	include []NameMatcher
+	exclude []NameMatcher
+}
+
+// ManyToManyRule declares the owner side of the join tables matching JoinTable,
+// a regular expression matched against the whole name. OwnerField and InverseField
+// name the collections of the owner and the other table in singular form; they
+// default to the name of the table the collection refers to.
+type ManyToManyRule struct {
+	JoinTable    string `json:"joinTable"`
+	Owner        string `json:"owner"`
+	OwnerField   string `json:"ownerField"`
+	InverseField string `json:"inverseField"`
+
+	joinTablePattern *regexp.Regexp
+}
+
+// TypeMappingRule overrides the Java type of the columns it matches. Empty fields
+// match any column; the first matching rule wins. Table and Column are regular
+// expressions matched against the whole name.
+type TypeMappingRule struct {
+	Dialect   string `json:"dialect"`
+	Table     string `json:"table"`
+	Column    string `json:"column"`
+	Type      string `json:"type"`
+	Length    *int   `json:"length"`
+	MinLength *int   `json:"minLength"`
+	MaxLength *int   `json:"maxLength"`
+	Scale     *int   `json:"scale"`
+	// JavaType and Converter may be fully qualified, in which case they are imported.
+	JavaType         string `json:"javaType"`
+	Converter        string `json:"converter"`
+	ColumnDefinition string `json:"columnDefinition"`
+
+	tablePattern  *regexp.Regexp
+	columnPattern *regexp.Regexp
+}
+
+// ReadConfig reads and validates a configuration file.
+func ReadConfig(fileName string) (Config, error) {
+	var result Config
+	file, err := os.Open(fileName)
+	if err != nil {
+		return result, fmt.Errorf("os open %v: %w", fileName, err)
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return result, fmt.Errorf("json decode %v: %w", fileName, err)
+	}
+	if err := result.Compile(); err != nil {
+		return result, fmt.Errorf("%v: %w", fileName, err)
+	}
+	return result, nil
+}
+
+// Compile validates the configuration and compiles its patterns. ReadConfig
+// compiles the configuration it returns; a Config built in code must be compiled
+// before it is used.
+func (c *Config) Compile() error {
+	var err error
+	for i := range c.TypeMappings {
+		rule := &c.TypeMappings[i]
+		if rule.JavaType == "" {
+			return fmt.Errorf("type mapping %v: javaType is required", i+1)
+		}
+		if rule.tablePattern, err = compileNamePattern(rule.Table); err != nil {
+			return fmt.Errorf("type mapping %v table: %w", i+1, err)
+		}
+		if rule.columnPattern, err = compileNamePattern(rule.Column); err != nil {
+			return fmt.Errorf("type mapping %v column: %w", i+1, err)
+		}
+	}
+	for i := range c.ManyToMany {
+		rule := &c.ManyToMany[i]
+		if rule.JoinTable == "" || rule.Owner == "" {
+			return fmt.Errorf("many to many %v: joinTable and owner are required", i+1)
+		}
+		if rule.joinTablePattern, err = compileNamePattern(rule.JoinTable); err != nil {
+			return fmt.Errorf("many to many %v join table: %w", i+1, err)
+		}
+	}
+	if err := c.Cascade.Validate(); err != nil {
+		return err
+	}
+	if err := c.Tables.compile(); err != nil {
+		return fmt.Errorf("tables: %w", err)
+	}
+	if err := c.Columns.compile(); err != nil {
+		return fmt.Errorf("columns: %w", err)
+	}
+	c.compiled = true
+	return nil
+}
+
+// Compiled reports whether the configuration is ready for use: Compile succeeded,
+// or the configuration has no rule or filter to compile, like the zero Config.
+func (c Config) Compiled() bool {
+	return c.compiled || len(c.TypeMappings) == 0 && len(c.ManyToMany) == 0 && len(c.Cascade) == 0 &&
+		len(c.Tables.Include) == 0 && len(c.Tables.Exclude) == 0 &&
+		len(c.Columns.Include) == 0 && len(c.Columns.Exclude) == 0
+}
+
+func (f *NameFilter) compile() error {
+	f.include, f.exclude = nil, nil
+	for _, pattern := range f.Include {
+		match, err := NewNameMatcher(pattern)
+		if err != nil {
+			return fmt.Errorf("include %v: %w", pattern, err)
+		}
+		f.include = append(f.include, match)
+	}
+	for _, pattern := range f.Exclude {
+		match, err := NewNameMatcher(pattern)
+		if err != nil {
+			return fmt.Errorf("exclude %v: %w", pattern, err)
+		}
+		f.exclude = append(f.exclude, match)
+	}
+	return nil
+}
+
+// allows reports whether the filter selects the thing known by names.
+func (f NameFilter) allows(names ...string) bool {
+	included := len(f.include) == 0
+	for _, name := range names {
+		for _, match := range f.exclude {
+			if match(name) {
+				return false
+			}
+		}
+		for _, match := range f.include {
+			if match(name) {
+				included = true
+			}
+		}
+	}
+	return included
+}
+
+// TableAllowed reports whether the table filter selects table.
+func (c Config) TableAllowed(table TableIdentity) bool {
+	return c.Tables.allows(table.Name, table.Schema+"."+table.Name)
+}
+
+// ColumnAllowed reports whether the column filter selects column of table.
+func (c Config) ColumnAllowed(table TableIdentity, column string) bool {
+	return c.Columns.allows(column, table.Name+"."+column)
+}
+
+// CascadeRules returns the configured cascade rules, or the default ones.
+func (c Config) CascadeRules() cascadeMapping.Rules {
+	if c.Cascade == nil {
+		return cascadeMapping.DefaultRules
+	}
+	return c.Cascade
+}
+
+// manyToManyRule returns the first rule declaring the owner of joinTable.
+func (c Config) manyToManyRule(joinTable TableIdentity) (ManyToManyRule, bool) {
+	for _, rule := range c.ManyToMany {
+		if rule.joinTablePattern.MatchString(joinTable.Name) {
+			return rule, true
+		}
+	}
+	return ManyToManyRule{}, false
+}
+
+// compileNamePattern compiles a case insensitive regular expression matching a whole name.
+// An empty pattern compiles to nil.
+func compileNamePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(`(?i)^(?:` + pattern + `)$`)
+}
+
+func (r TypeMappingRule) matches(dialect string, table TableIdentity, col ColumnDef) bool {
+	switch {
+	case r.Dialect != "" && r.Dialect != dialect:
+	case r.Type != "" && !strings.EqualFold(r.Type, col.Type):
+	case r.Length != nil && *r.Length != col.Length:
+	case r.MinLength != nil && col.Length < *r.MinLength:
+	case r.MaxLength != nil && col.Length > *r.MaxLength:
+	case r.Scale != nil && *r.Scale != col.Scale:
+	case r.tablePattern != nil && !r.tablePattern.MatchString(table.Name):
+	case r.columnPattern != nil && !r.columnPattern.MatchString(col.Name):
+	default:
+		return true
+	}
+	return false
+}
+
+// splitQualifiedName returns the import for a possibly fully qualified Java class name and its simple name.
+func splitQualifiedName(name string) (string, string) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name, name[i+1:]
+	}
+	return "", name
+}
+
+// FilterTableDef drops the columns excluded by the configuration, except primary key
+// columns, and the foreign keys using them or referencing excluded tables.
+func (c Config) FilterTableDef(table TableDef) TableDef {
+	result := table
+	result.Columns = nil
+	result.ForeignKeys = nil
+	for _, col := range table.Columns {
+		if table.PrimaryKeys[col.Name] || c.ColumnAllowed(table.TableIdentity, col.Name) {
+			result.Columns = append(result.Columns, col)
+		}
+	}
+	for _, fk := range table.ForeignKeys {
+		allowed := c.TableAllowed(fk.To)
+		for _, colName := range fk.FkColumns {
+			if !table.PrimaryKeys[colName] && !c.ColumnAllowed(table.TableIdentity, colName) {
+				allowed = false
+			}
+		}
+		if allowed {
+			result.ForeignKeys = append(result.ForeignKeys, fk)
+		}
+	}
+	return result
+}
@@ -0,0 +1,150 @@
+package entityModel
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNameFilter(t *testing.T) {
+	config := Config{
+		Tables:  NameFilter{Exclude: []string{"*_BAK", "*_TMP", "/AUDIT_[0-9]+/", "ARCHIVE.*"}},
+		Columns: NameFilter{Include: []string{"ID", "*_ID", "ORDERS.*"}, Exclude: []string{"ORDERS.ROW_VERSION"}},
+	}
+	if err := config.Compile(); err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	tables := []struct {
+		schema, name string
+		allowed      bool
+	}{
+		{"ONLDB", "ORDERS", true},
+		{"ONLDB", "ORDERS_BAK", false},
+		{"ONLDB", "orders_tmp", false},
+		{"ONLDB", "AUDIT_2020", false},
+		{"ONLDB", "AUDIT_LOG", true},
+		{"ARCHIVE", "ORDERS", false},
+	}
+	for _, test := range tables {
+		if allowed := config.TableAllowed(TableIdentity{Schema: test.schema, Name: test.name}); allowed != test.allowed {
+			t.Errorf("table %v.%v allowed %v, want %v", test.schema, test.name, allowed, test.allowed)
+		}
+	}
+	columns := []struct {
+		table, column string
+		allowed       bool
+	}{
+		{"CUSTOMER", "ID", true},
+		{"CUSTOMER", "ORDER_ID", true},
+		{"CUSTOMER", "NAME", false},
+		{"ORDERS", "NAME", true},
+		{"ORDERS", "ROW_VERSION", false},
+	}
+	for _, test := range columns {
+		if allowed := config.ColumnAllowed(TableIdentity{Schema: "ONLDB", Name: test.table}, test.column); allowed != test.allowed {
+			t.Errorf("column %v.%v allowed %v, want %v", test.table, test.column, allowed, test.allowed)
+		}
+	}
+}
+
+func TestNameFilterErrors(t *testing.T) {
+	for _, filter := range []NameFilter{
+		{Include: []string{"ORDER_[A-"}},
+		{Exclude: []string{"/ORDER_(/"}},
+	} {
+		config := Config{Tables: filter}
+		if err := config.Compile(); err == nil {
+			t.Errorf("%v compiled", filter)
+		}
+	}
+}
+
+// dialectSchema is a schema of which the type mapping only reads the dialect.
+type dialectSchema struct {
+	SchemaProvider
+	dialect string
+}
+
+func (s dialectSchema) Dialect() string {
+	return s.dialect
+}
+
+func intPointer(i int) *int {
+	return &i
+}
+
+func TestTypeMappings(t *testing.T) {
+	config := Config{TypeMappings: []TypeMappingRule{
+		{Type: "DECIMAL", Scale: intPointer(0), MaxLength: intPointer(9), JavaType: "Integer"},
+		{Type: "DECIMAL", Scale: intPointer(0), MinLength: intPointer(10), MaxLength: intPointer(18), JavaType: "java.lang.Long"},
+		{Type: "CHARACTER", Length: intPointer(1), Column: ".*_FLAG", JavaType: "Boolean", Converter: "com.example.convert.YesNoConverter"},
+		{Type: "TIMESTAMP", Table: "AUDIT_.*", JavaType: "java.time.OffsetDateTime", ColumnDefinition: "TIMESTAMP WITH TIME ZONE"},
+		{Dialect: "postgres", Type: "DATE", JavaType: "String"},
+	}}
+	if err := config.Compile(); err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	tests := []struct {
+		name       string
+		table      string
+		col        ColumnDef
+		primitives bool
+		want       ColumnWithType
+	}{
+		{"max length", "ORDERS", ColumnDef{Name: "QTY", Type: "DECIMAL", Length: 9, Nullable: true},
+			false, ColumnWithType{JavaType: "Integer"}},
+		{"scale", "ORDERS", ColumnDef{Name: "PRICE", Type: "DECIMAL", Length: 9, Scale: 2, Nullable: true},
+			false, ColumnWithType{JavaType: "BigDecimal"}},
+		{"length range", "ORDERS", ColumnDef{Name: "TOTAL", Type: "DECIMAL", Length: 15, Nullable: true},
+			false, ColumnWithType{JavaType: "Long", Imports: []string{"java.lang.Long"}}},
+		{"primitive of a qualified type", "ORDERS", ColumnDef{Name: "TOTAL", Type: "DECIMAL", Length: 15},
+			true, ColumnWithType{JavaType: "long"}},
+		{"primitive of a mapped type", "ORDERS", ColumnDef{Name: "QTY", Type: "DECIMAL", Length: 5},
+			true, ColumnWithType{JavaType: "int"}},
+		{"primary key stays a wrapper", "ORDERS", ColumnDef{Name: "ID", Type: "DECIMAL", Length: 5},
+			true, ColumnWithType{JavaType: "Integer"}},
+		{"converter", "ORDERS", ColumnDef{Name: "ACTIVE_FLAG", Type: "CHARACTER", Length: 1},
+			true, ColumnWithType{JavaType: "boolean", Converter: "YesNoConverter", Imports: []string{"com.example.convert.YesNoConverter"}}},
+		{"column pattern", "ORDERS", ColumnDef{Name: "STATUS", Type: "CHARACTER", Length: 1, Nullable: true},
+			false, ColumnWithType{JavaType: "String"}},
+		{"table pattern", "audit_log", ColumnDef{Name: "LOGGED_AT", Type: "TIMESTAMP", Length: 10, Scale: 6, Nullable: true},
+			false, ColumnWithType{JavaType: "OffsetDateTime", ColumnDefinition: "TIMESTAMP WITH TIME ZONE", Imports: []string{"java.time.OffsetDateTime"}}},
+		{"other table", "ORDERS", ColumnDef{Name: "LOGGED_AT", Type: "TIMESTAMP", Length: 10, Scale: 6, Nullable: true},
+			false, ColumnWithType{JavaType: "LocalDateTime"}},
+		{"other dialect", "ORDERS", ColumnDef{Name: "DUE", Type: "DATE", Nullable: true},
+			false, ColumnWithType{JavaType: "LocalDate"}},
+	}
+	for _, test := range tests {
+		a := Analyzer{Schema: dialectSchema{dialect: "db2"}, Config: config, Primitives: test.primitives}
+		table := TableDef{
+			TableIdentity: TableIdentity{Schema: "ONLDB", Name: test.table},
+			PrimaryKeys:   map[string]bool{"ID": true},
+		}
+		got, err := a.columnWithType(JavaTypes["db2"], table, test.col)
+		if err != nil {
+			t.Errorf("%v: %v", test.name, err)
+			continue
+		}
+		test.want.ColumnDef = test.col
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v:\n got %+v\nwant %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTypeMappingErrors(t *testing.T) {
+	tests := []struct {
+		rule TypeMappingRule
+		err  string
+	}{
+		{TypeMappingRule{Type: "DATE"}, "type mapping 1: javaType is required"},
+		{TypeMappingRule{Table: "ORDER_(", JavaType: "String"}, "type mapping 1 table:"},
+		{TypeMappingRule{Column: "[", JavaType: "String"}, "type mapping 1 column:"},
+	}
+	for _, test := range tests {
+		config := Config{TypeMappings: []TypeMappingRule{test.rule}}
+		if err := config.Compile(); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%+v: error %v, want %q", test.rule, err, test.err)
+		}
+	}
+}
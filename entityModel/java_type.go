@@ -0,0 +1,55 @@
+package entityModel
+
+import (
+	"strings"
+)
+
+// JavaTypes maps the columns of each dialect to Java types. A mapping returns an
+// empty string for the types it does not know.
+var JavaTypes = map[string]func(col ColumnDef) string{
+	"db2":      db2TypeToJavaType,
+	"postgres": postgresTypeToJavaType,
+	"sqlite":   sqliteTypeToJavaType,
+	"mysql":    mysqlTypeToJavaType,
+	"oracle":   oracleTypeToJavaType,
+}
+
+// db2TypeToJavaType maps a DB2 column by its syscat.columns type name to a Java type.
+func db2TypeToJavaType(col ColumnDef) string {
+	if strings.HasPrefix(col.Type, "TIMESTAMP") {
+		if strings.HasSuffix(col.Type, "WITH TIME ZONE") {
+			return "OffsetDateTime"
+		}
+		// TIMESTAMP and TIMESTAMP(p)
+		return "LocalDateTime"
+	}
+	switch col.Type {
+	case "DATE":
+		return "LocalDate"
+	case "TIME":
+		return "LocalTime"
+	case "VARCHAR", "CHARACTER", "CHAR", "LONG VARCHAR", "CLOB":
+		return "String"
+	case "VARGRAPHIC", "GRAPHIC", "LONG VARGRAPHIC", "DBCLOB":
+		return "String"
+	case "XML":
+		return "String"
+	case "SMALLINT":
+		return "Short"
+	case "INTEGER":
+		return "Integer"
+	case "BIGINT":
+		return "Long"
+	case "REAL":
+		return "Float"
+	case "DOUBLE", "FLOAT":
+		return "Double"
+	case "DECIMAL", "DECFLOAT":
+		return "BigDecimal"
+	case "BOOLEAN":
+		return "Boolean"
+	case "BLOB", "BINARY", "VARBINARY":
+		return "byte[]"
+	}
+	return ""
+}
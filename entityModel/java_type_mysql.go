@@ -0,0 +1,38 @@
+package entityModel
+
+// mysqlTypeToJavaType maps a column by its information_schema.columns.data_type to a Java type.
+func mysqlTypeToJavaType(col ColumnDef) string {
+	switch col.Type {
+	case "TINYINT":
+		if col.Length == 1 {
+			return "Boolean"
+		}
+		return "Integer"
+	case "BIT":
+		if col.Length == 1 {
+			return "Boolean"
+		}
+		return "byte[]"
+	case "SMALLINT", "MEDIUMINT", "INT", "YEAR":
+		return "Integer"
+	case "BIGINT":
+		return "Long"
+	case "DECIMAL":
+		return "BigDecimal"
+	case "FLOAT":
+		return "Float"
+	case "DOUBLE":
+		return "Double"
+	case "CHAR", "VARCHAR", "TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT", "ENUM", "SET", "JSON":
+		return "String"
+	case "DATE":
+		return "LocalDate"
+	case "DATETIME", "TIMESTAMP":
+		return "LocalDateTime"
+	case "TIME":
+		return "LocalTime"
+	case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB":
+		return "byte[]"
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package entityModel
+
+import (
+	"strings"
+)
+
+// oracleTypeToJavaType maps a column by its ALL_TAB_COLUMNS.DATA_TYPE to a Java type.
+// NUMBER columns without scale become Integer or Long when their precision allows.
+func oracleTypeToJavaType(col ColumnDef) string {
+	switch {
+	case strings.HasPrefix(col.Type, "TIMESTAMP") && strings.HasSuffix(col.Type, "WITH TIME ZONE"):
+		return "OffsetDateTime"
+	case strings.HasPrefix(col.Type, "TIMESTAMP"):
+		return "LocalDateTime"
+	}
+	switch col.Type {
+	case "NUMBER":
+		if col.Length > 0 && col.Scale == 0 {
+			if col.Length <= 9 {
+				return "Integer"
+			}
+			if col.Length <= 18 {
+				return "Long"
+			}
+		}
+		return "BigDecimal"
+	case "FLOAT", "BINARY_DOUBLE":
+		return "Double"
+	case "BINARY_FLOAT":
+		return "Float"
+	case "CHAR", "NCHAR", "VARCHAR2", "NVARCHAR2", "CLOB", "NCLOB", "LONG":
+		return "String"
+	case "DATE":
+		// Oracle DATE carries a time of day
+		return "LocalDateTime"
+	case "RAW", "LONG RAW", "BLOB":
+		return "byte[]"
+	}
+	return ""
+}
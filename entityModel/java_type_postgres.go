@@ -0,0 +1,38 @@
+package entityModel
+
+// postgresTypeToJavaType maps a column by its pg_type name (information_schema.columns.udt_name) to a Java type.
+func postgresTypeToJavaType(col ColumnDef) string {
+	switch col.Type {
+	case "int2":
+		return "Short"
+	case "int4":
+		return "Integer"
+	case "int8":
+		return "Long"
+	case "numeric":
+		return "BigDecimal"
+	case "float4":
+		return "Float"
+	case "float8":
+		return "Double"
+	case "bool":
+		return "Boolean"
+	case "varchar", "bpchar", "text", "name", "citext", "json", "jsonb", "xml":
+		return "String"
+	case "date":
+		return "LocalDate"
+	case "time":
+		return "LocalTime"
+	case "timetz":
+		return "OffsetTime"
+	case "timestamp":
+		return "LocalDateTime"
+	case "timestamptz":
+		return "OffsetDateTime"
+	case "uuid":
+		return "UUID"
+	case "bytea":
+		return "byte[]"
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package entityModel
+
+import (
+	"strings"
+)
+
+// sqliteTypeToJavaType maps a declared column type to a Java type, falling back
+// to the SQLite type affinity rules for names it does not know.
+func sqliteTypeToJavaType(col ColumnDef) string {
+	typeName := col.Type
+	switch typeName {
+	case "DATE":
+		return "LocalDate"
+	case "DATETIME", "TIMESTAMP":
+		return "LocalDateTime"
+	case "TIME":
+		return "LocalTime"
+	case "BOOLEAN":
+		return "Boolean"
+	case "SMALLINT":
+		return "Short"
+	case "INTEGER", "INT":
+		return "Integer"
+	case "BIGINT":
+		return "Long"
+	case "DECIMAL", "NUMERIC":
+		return "BigDecimal"
+	}
+	switch {
+	case strings.Contains(typeName, "INT"):
+		return "Long"
+	case strings.Contains(typeName, "CHAR"), strings.Contains(typeName, "CLOB"), strings.Contains(typeName, "TEXT"):
+		return "String"
+	case typeName == "", strings.Contains(typeName, "BLOB"):
+		return "byte[]"
+	case strings.Contains(typeName, "REAL"), strings.Contains(typeName, "FLOA"), strings.Contains(typeName, "DOUB"):
+		return "Double"
+	}
+	return "BigDecimal"
+}
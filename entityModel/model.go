@@ -0,0 +1,49 @@
+// Package entityModel derives the entities of tables, their Java types and the
+// relations between them, from the definitions of a tableDefinition.SchemaProvider.
+package entityModel
+
+import (
+	"tnd/work/generateJavaEntity/tableDefinition"
+)
+
+type ColumnDef = tableDefinition.ColumnDef
+type ForeignKey = tableDefinition.ForeignKey
+type TableDef = tableDefinition.TableDef
+type TableIdentity = tableDefinition.TableIdentity
+type SchemaProvider = tableDefinition.SchemaProvider
+
+type ExtraRelation struct {
+	TableIdentity
+	Annotation []string
+	ToMany     bool
+	OwnField   bool
+	TypeName   string
+	FieldName  string
+	MappedBy   string
+	// DeleteRule is the ON DELETE rule of the foreign key of an inverse relation.
+	DeleteRule string
+	Cascade    bool
+}
+
+type ColumnWithType struct {
+	ColumnDef
+	JavaType         string
+	Converter        string
+	ColumnDefinition string
+	Imports          []string
+}
+
+type TableWithRelation struct {
+	TableIdentity
+	TypeName     string
+	IdType       string
+	IdField      string
+	CompositeKey bool             // primary key mapped by the @Embeddable class IdType
+	IdColumns    []ColumnWithType // primary key columns of a CompositeKey
+	Stub         bool             // reference stub of a table that is not generated
+	Audited      bool
+	PrimaryKeys  map[string]bool
+	NoSeq        bool
+	BasicColumns []ColumnWithType
+	Relations    []ExtraRelation
+}
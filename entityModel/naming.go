@@ -0,0 +1,93 @@
+package entityModel
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// CamelCase converts a database name such as ORDER_ITEM to orderItem.
+func CamelCase(colName string) string {
+	tokens := strings.Split(colName, "_")
+	for i := range tokens {
+		tokens[i] = strings.ToLower(tokens[i])
+		if i > 0 {
+			tokens[i] = strings.Title(tokens[i])
+		}
+	}
+	return strings.Join(tokens, "")
+}
+
+// CamelToHyphen converts orderItem to order-item.
+func CamelToHyphen(val string) string {
+	var result []rune
+	for i, r := range val {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				result = append(result, '-')
+			}
+			result = append(result, r-'A'+'a')
+		} else {
+			result = append(result, r)
+		}
+	}
+	return string(result)
+}
+
+// PluralName returns the English plural of a field name.
+func PluralName(name string) string {
+	if strings.HasSuffix(name, "child") || strings.HasSuffix(name, "Child") {
+		return name + "ren"
+	}
+	if len(name) >= 2 {
+		if name[len(name)-1] == 'y' {
+			switch name[len(name)-2] {
+			case 'a', 'e', 'i', 'o', 'u':
+				return name + "s"
+			default:
+				return name[:len(name)-1] + "ies"
+			}
+		}
+	}
+	for _, suf := range []string{"s", "ss", "z", "ch", "sh", "x"} {
+		if strings.HasSuffix(name, suf) {
+			return name + "es"
+		}
+	}
+	return name + "s"
+}
+
+// NameMatcher reports whether a name matches a pattern of NewNameMatcher.
+type NameMatcher func(name string) bool
+
+// IsNamePattern reports whether entry is a glob or a regular expression between
+// slashes rather than a plain name.
+func IsNamePattern(entry string) bool {
+	return isRegexpEntry(entry) || strings.ContainsAny(entry, "*?[")
+}
+
+func isRegexpEntry(entry string) bool {
+	return len(entry) > 1 && strings.HasPrefix(entry, "/") && strings.HasSuffix(entry, "/")
+}
+
+// NewNameMatcher compiles a case insensitive name pattern, the syntax of -table and
+// of the table and column filters: a regular expression between slashes such as
+// /ORDER_[0-9]+/ matched against the whole name, or else a path.Match glob such as
+// ORDER_*, which a plain name is too.
+func NewNameMatcher(pattern string) (NameMatcher, error) {
+	if isRegexpEntry(pattern) {
+		re, err := regexp.Compile(`(?i)^(?:` + pattern[1:len(pattern)-1] + `)$`)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+	pattern = strings.ToUpper(pattern)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	return func(name string) bool {
+		ok, _ := path.Match(pattern, strings.ToUpper(name))
+		return ok
+	}, nil
+}
@@ -0,0 +1,470 @@
+package entityModel
+
+import (
+	"fmt"
+	"strings"
+	"tnd/work/generateJavaEntity/cascadeMapping"
+	"tnd/work/generateJavaEntity/tableDefinition"
+)
+
+// Analyzer derives the entities of the tables of Schema.
+type Analyzer struct {
+	Schema SchemaProvider
+	Config Config
+	// Primitives maps NOT NULL columns to primitive Java types.
+	Primitives bool
+	// CascadeByDeleteRule cascades the relations of ON DELETE CASCADE foreign keys
+	// instead of applying the cascade rules of Config.
+	CascadeByDeleteRule bool
+	// Warn reports the problems that do not stop the analysis, it may be nil.
+	Warn func(format string, a ...interface{})
+}
+
+func (a Analyzer) warnf(format string, args ...interface{}) {
+	if a.Warn != nil {
+		a.Warn(format, args...)
+	}
+}
+
+// IsTableManyToManyRelation reports whether table is a plain join table. A join
+// table with more columns is an association entity with a composite key whose
+// foreign keys are mapped with @MapsId.
+func IsTableManyToManyRelation(table TableDef) bool {
+	if len(table.Columns) == 2 {
+		if len(table.ForeignKeys) == 2 {
+			if len(table.PrimaryKeys) == 2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// javaPrimitiveTypes maps wrapper types to the primitive used for NOT NULL columns.
+var javaPrimitiveTypes = map[string]string{
+	"Boolean": "boolean",
+	"Short":   "short",
+	"Integer": "int",
+	"Long":    "long",
+	"Float":   "float",
+	"Double":  "double",
+}
+
+// columnWithType resolves the Java type of a column from the configured type
+// mappings, falling back to the type mapping of the dialect. With Primitives, NOT
+// NULL columns of either kind use the primitive of their wrapper type.
+func (a Analyzer) columnWithType(javaType func(col ColumnDef) string, table TableDef, col ColumnDef) (ColumnWithType, error) {
+	dialectName := a.Schema.Dialect()
+	result := ColumnWithType{ColumnDef: col}
+	var typeImport string
+	mapped := false
+	for _, rule := range a.Config.TypeMappings {
+		if rule.matches(dialectName, table.TableIdentity, col) {
+			typeImport, result.JavaType = splitQualifiedName(rule.JavaType)
+			var imp string
+			if imp, result.Converter = splitQualifiedName(rule.Converter); imp != "" {
+				result.Imports = append(result.Imports, imp)
+			}
+			result.ColumnDefinition = rule.ColumnDefinition
+			mapped = true
+			break
+		}
+	}
+	if !mapped {
+		result.JavaType = javaType(col)
+		if result.JavaType == "" {
+			return result, fmt.Errorf("table %v.%v column %v: unsupported %v type %v", table.Schema, table.Name, col.Name, dialectName, col.Type)
+		}
+	}
+	// identifiers and database generated values stay wrappers so that they can be null before insert
+	if a.Primitives && !col.Nullable && !col.Identity && !col.Generated && !table.PrimaryKeys[col.Name] {
+		if primitive, ok := javaPrimitiveTypes[result.JavaType]; ok {
+			result.JavaType = primitive
+			typeImport = ""
+		}
+	}
+	if typeImport != "" {
+		result.Imports = append([]string{typeImport}, result.Imports...)
+	}
+	return result, nil
+}
+
+// fkIsPrimaryKey reports whether the columns of fk are exactly the primary key of table.
+func fkIsPrimaryKey(table TableDef, fk ForeignKey) bool {
+	for _, colName := range fk.FkColumns {
+		if !table.PrimaryKeys[colName] {
+			return false
+		}
+	}
+	return len(fk.FkColumns) > 0 && len(fk.FkColumns) == len(table.PrimaryKeys)
+}
+
+// fkFieldName names the owning field of a foreign key after its columns without the
+// referenced column suffix, DEPT_ID referencing ID becomes dept. Multi-column keys
+// whose columns do not share such a prefix are named after the referenced table.
+func fkFieldName(fk ForeignKey) string {
+	var fieldName string
+	for i, colName := range fk.FkColumns {
+		name := colName
+		if i < len(fk.PkColumns) && strings.HasSuffix(colName, "_"+fk.PkColumns[i]) {
+			name = colName[:len(colName)-len(fk.PkColumns[i])-1]
+		}
+		if i > 0 && name != fieldName {
+			return CamelCase(fk.To.Name)
+		}
+		fieldName = name
+	}
+	return CamelCase(fieldName)
+}
+
+// isParentReference reports whether fk is the only foreign key of table that
+// references table itself. It is mapped as a parent/children pair.
+func isParentReference(table TableDef, fk ForeignKey) bool {
+	if fk.From != fk.To {
+		return false
+	}
+	for _, otherFk := range table.ForeignKeys {
+		if otherFk.Constname != fk.Constname && otherFk.From == otherFk.To {
+			return false
+		}
+	}
+	return true
+}
+
+// hasForeignKey reports whether fk is one of the foreign keys of table.
+func hasForeignKey(table TableDef, fk ForeignKey) bool {
+	for _, tableFk := range table.ForeignKeys {
+		if tableFk.Constname == fk.Constname {
+			return true
+		}
+	}
+	return false
+}
+
+// countForeignKeysTo returns the number of foreign keys of table referencing to.
+func countForeignKeysTo(table TableDef, to TableIdentity) int {
+	var count int
+	for _, fk := range table.ForeignKeys {
+		if fk.To == to {
+			count++
+		}
+	}
+	return count
+}
+
+// relationFieldName returns the name of the field mapping fk in the entity of table.
+func relationFieldName(table TableDef, fk ForeignKey) string {
+	if isParentReference(table, fk) {
+		return "parent"
+	}
+	return fkFieldName(fk)
+}
+
+// joinColumnList returns a @JoinColumn per column of fk, naming the referenced
+// column when there is more than one. spec is appended to every annotation.
+func joinColumnList(fk ForeignKey, spec string) []string {
+	var result []string
+	for i, colName := range fk.FkColumns {
+		if len(fk.FkColumns) > 1 && i < len(fk.PkColumns) {
+			result = append(result, `@JoinColumn(name="`+colName+`", referencedColumnName="`+fk.PkColumns[i]+`"`+spec+`)`)
+		} else {
+			result = append(result, `@JoinColumn(name="`+colName+`"`+spec+`)`)
+		}
+	}
+	return result
+}
+
+// GetTableRelation maps table to an entity with the relations of its foreign keys
+// and of the foreign keys referencing it.
+func (a Analyzer) GetTableRelation(table TableDef) (TableWithRelation, error) {
+	table = a.Config.FilterTableDef(table)
+	var result TableWithRelation
+	result.TableIdentity = table.TableIdentity
+	result.TypeName = strings.Title(CamelCase(result.Name))
+	result.PrimaryKeys = table.PrimaryKeys
+	javaType, ok := JavaTypes[a.Schema.Dialect()]
+	if !ok {
+		return result, fmt.Errorf("no Java types for dialect %v", a.Schema.Dialect())
+	}
+	fks, err := a.Schema.ListForeignKeysTo(table.TableIdentity)
+	if err != nil {
+		return result, err
+	}
+	for _, fk := range fks {
+		if !a.Config.TableAllowed(fk.From) {
+			continue
+		}
+		fkTable, err := tableDefinition.GetTableDef(a.Schema, fk.From)
+		if err != nil {
+			return result, fmt.Errorf("get table def %v: %w", fk.From, err)
+		}
+		fkTable = a.Config.FilterTableDef(fkTable)
+		if !hasForeignKey(fkTable, fk) {
+			continue
+		}
+		if IsTableManyToManyRelation(fkTable) {
+			for _, otherFk := range fkTable.ForeignKeys {
+				if otherFk.Constname == fk.Constname {
+					continue
+				}
+				var owner bool
+				var ownerField, inverseField string
+				if rule, ok := a.Config.manyToManyRule(fkTable.TableIdentity); ok {
+					owner = strings.EqualFold(rule.Owner, table.Name)
+					if !owner && !strings.EqualFold(rule.Owner, otherFk.To.Name) {
+						a.warnf("many to many %v.%v: configured owner %v is not one of its tables", fkTable.Schema, fkTable.Name, rule.Owner)
+						continue
+					}
+					ownerField, inverseField = rule.OwnerField, rule.InverseField
+					if owner && ownerField == "" {
+						ownerField = CamelCase(otherFk.To.Name)
+					} else if !owner && inverseField == "" {
+						inverseField = CamelCase(otherFk.To.Name)
+					}
+					if !owner && ownerField == "" {
+						ownerField = CamelCase(table.Name)
+					}
+				} else if strings.HasPrefix(fkTable.Name, table.Name) {
+					owner = true
+					ownerField = CamelCase(fkTable.Name[len(table.Name)+1:])
+				} else if strings.HasPrefix(fkTable.Name, otherFk.To.Name) {
+					ownerField = CamelCase(fkTable.Name[len(otherFk.To.Name)+1:])
+					inverseField = CamelCase(otherFk.To.Name)
+				} else {
+					a.warnf("many to many %v.%v: can't determine which table is owner, declare it in the manyToMany config", fkTable.Schema, fkTable.Name)
+					continue
+				}
+				if owner {
+					result.Relations = append(result.Relations, ExtraRelation{
+						Annotation: []string{
+							`@ManyToMany(fetch=FetchType.LAZY)`,
+							`@JoinTable(name="` + fkTable.Name + `", schema="` + fkTable.Schema + `",`,
+							`	joinColumns={` + strings.Join(joinColumnList(fk, ""), ", ") + `},`,
+							`	inverseJoinColumns={` + strings.Join(joinColumnList(otherFk, ""), ", ") + `}`,
+							`)`,
+						},
+						ToMany:        true,
+						OwnField:      true,
+						TableIdentity: otherFk.To,
+						TypeName:      strings.Title(CamelCase(otherFk.To.Name)),
+						FieldName:     ownerField,
+					})
+				} else {
+					result.Relations = append(result.Relations, ExtraRelation{
+						Annotation: []string{
+							`@ManyToMany(fetch=FetchType.LAZY, mappedBy="` + PluralName(ownerField) + `")`,
+						},
+						ToMany:        true,
+						OwnField:      false,
+						MappedBy:      PluralName(ownerField),
+						TableIdentity: otherFk.To,
+						TypeName:      strings.Title(CamelCase(otherFk.To.Name)),
+						FieldName:     inverseField,
+					})
+				}
+			}
+		} else {
+			mappedBy := relationFieldName(fkTable, fk)
+			fieldName := CamelCase(fkTable.Name)
+			if isParentReference(fkTable, fk) {
+				fieldName = "child"
+			} else if countForeignKeysTo(fkTable, fk.To) > 1 {
+				fieldName = mappedBy + strings.Title(fieldName)
+			}
+			if fkIsPrimaryKey(fkTable, fk) {
+				result.Relations = append(result.Relations, ExtraRelation{
+					Annotation: []string{
+						`@OneToOne(fetch=FetchType.LAZY, mappedBy="` + mappedBy + `")`,
+					},
+					ToMany:        false,
+					OwnField:      false,
+					MappedBy:      mappedBy,
+					DeleteRule:    fk.DeleteRule,
+					TableIdentity: fkTable.TableIdentity,
+					TypeName:      strings.Title(CamelCase(fkTable.Name)),
+					FieldName:     fieldName,
+				})
+			} else {
+				result.Relations = append(result.Relations, ExtraRelation{
+					Annotation: []string{
+						`@OneToMany(fetch=FetchType.LAZY, mappedBy="` + mappedBy + `")`,
+					},
+					ToMany:        true,
+					OwnField:      false,
+					MappedBy:      mappedBy,
+					DeleteRule:    fk.DeleteRule,
+					TableIdentity: fkTable.TableIdentity,
+					TypeName:      strings.Title(CamelCase(fkTable.Name)),
+					FieldName:     fieldName,
+				})
+			}
+		}
+	}
+	fkColumn := make(map[string]ForeignKey)
+	for _, fk := range table.ForeignKeys {
+		for _, colName := range fk.FkColumns {
+			if _, ok := fkColumn[colName]; !ok {
+				fkColumn[colName] = fk
+			}
+		}
+	}
+	addBasicColumn := func(col ColumnDef) error {
+		colWithType, err := a.columnWithType(javaType, table, col)
+		if err != nil {
+			return err
+		}
+		result.BasicColumns = append(result.BasicColumns, colWithType)
+		if result.PrimaryKeys[col.Name] {
+			result.IdType = colWithType.JavaType
+			result.IdField = CamelCase(col.Name)
+		}
+		return nil
+	}
+	result.CompositeKey = len(table.PrimaryKeys) > 1 && !IsTableManyToManyRelation(table)
+	if result.CompositeKey {
+		result.IdType = result.TypeName + "Id"
+		result.IdField = "id"
+		result.NoSeq = true
+	}
+	// a multi-column foreign key becomes one relation, emitted at its first column
+	fkDone := make(map[string]bool)
+	for _, col := range table.Columns {
+		isKey := table.PrimaryKeys[col.Name]
+		if result.CompositeKey && isKey {
+			colWithType, err := a.columnWithType(javaType, table, col)
+			if err != nil {
+				return result, err
+			}
+			result.IdColumns = append(result.IdColumns, colWithType)
+		}
+		fk, isFk := fkColumn[col.Name]
+		switch {
+		case isFk && !result.CompositeKey && fkIsPrimaryKey(table, fk):
+			result.Relations = append(result.Relations, ExtraRelation{
+				Annotation: []string{
+					`@OneToOne(fetch=FetchType.LAZY)`,
+					`@MapsId`,
+				},
+				ToMany:        false,
+				OwnField:      true,
+				TableIdentity: fk.To,
+				TypeName:      strings.Title(CamelCase(fk.To.Name)),
+				FieldName:     relationFieldName(table, fk),
+			})
+			if err := addBasicColumn(col); err != nil {
+				return result, err
+			}
+			result.NoSeq = true
+		case isFk && result.CompositeKey && isKey && len(fk.FkColumns) == 1:
+			// the relation supplies the value of its key field
+			result.Relations = append(result.Relations, ExtraRelation{
+				Annotation: []string{
+					`@ManyToOne(fetch=FetchType.LAZY)`,
+					`@MapsId("` + CamelCase(col.Name) + `")`,
+					`@JoinColumn(name="` + col.Name + `")`,
+				},
+				ToMany:        false,
+				OwnField:      true,
+				TableIdentity: fk.To,
+				TypeName:      strings.Title(CamelCase(fk.To.Name)),
+				FieldName:     relationFieldName(table, fk),
+			})
+		case isFk:
+			// a foreign key sharing columns with the primary key is read only on the
+			// relation side, so all of its columns are mapped as columns as well
+			var joinColumnSpec string
+			for _, colName := range fk.FkColumns {
+				if table.PrimaryKeys[colName] {
+					joinColumnSpec = `, insertable=false, updatable=false`
+				}
+			}
+			if joinColumnSpec != "" && !(result.CompositeKey && isKey) {
+				if err := addBasicColumn(col); err != nil {
+					return result, err
+				}
+			}
+			if fkDone[fk.Constname] {
+				continue
+			}
+			fkDone[fk.Constname] = true
+			annotation := []string{`@ManyToOne(fetch=FetchType.LAZY)`}
+			if fkIsPrimaryKey(table, fk) {
+				annotation[0] = `@OneToOne(fetch=FetchType.LAZY)`
+			}
+			if joinColumns := joinColumnList(fk, joinColumnSpec); len(joinColumns) == 1 {
+				annotation = append(annotation, joinColumns[0])
+			} else {
+				annotation = append(annotation, `@JoinColumns({`)
+				for i, joinColumn := range joinColumns {
+					if i < len(joinColumns)-1 {
+						joinColumn += ","
+					}
+					annotation = append(annotation, "\t"+joinColumn)
+				}
+				annotation = append(annotation, `})`)
+			}
+			result.Relations = append(result.Relations, ExtraRelation{
+				Annotation:    annotation,
+				ToMany:        false,
+				OwnField:      true,
+				TableIdentity: fk.To,
+				TypeName:      strings.Title(CamelCase(fk.To.Name)),
+				FieldName:     relationFieldName(table, fk),
+			})
+		case result.CompositeKey && isKey:
+		default:
+			switch strings.ToUpper(col.Name) {
+			case "MODIFIED_BY", "MODIFIED_DATE", "CREATED_BY", "CREATED_DATE":
+				result.Audited = true
+			default:
+				if err := addBasicColumn(col); err != nil {
+					return result, err
+				}
+			}
+		}
+	}
+
+	for i, relation := range result.Relations {
+		if rule, ok := a.cascadeRule(result.TableIdentity, relation); ok {
+			result.Relations[i].Cascade = true
+			mapping := relation.Annotation[0]
+			if !strings.Contains(mapping, "ToOne") && rule.RemovesOrphans() {
+				mapping = mapping[:len(mapping)-1] + `, cascade=` + rule.CascadeSpec() + `, orphanRemoval=true)`
+			} else {
+				mapping = mapping[:len(mapping)-1] + `, cascade=` + rule.CascadeSpec() + `)`
+			}
+			relation.Annotation[0] = mapping
+		}
+	}
+	return result, nil
+}
+
+// cascadeRule returns the cascade rule applying to relation of table.
+func (a Analyzer) cascadeRule(table TableIdentity, relation ExtraRelation) (cascadeMapping.Rule, bool) {
+	if a.CascadeByDeleteRule {
+		return cascadeMapping.Rule{}, relation.DeleteRule == "CASCADE"
+	}
+	return a.Config.CascadeRules().Find(table, relation.TableIdentity)
+}
+
+// ReferenceStub reduces table to its primary key and to the owning relations to
+// generated tables, which the mappedBy of their inverse fields refers to.
+func ReferenceStub(table TableWithRelation, generated map[TableIdentity]bool) TableWithRelation {
+	result := table
+	result.Stub = true
+	result.Audited = false
+	result.NoSeq = true
+	result.BasicColumns = nil
+	result.Relations = nil
+	for _, col := range table.BasicColumns {
+		if table.PrimaryKeys[col.Name] {
+			result.BasicColumns = append(result.BasicColumns, col)
+		}
+	}
+	for _, relation := range table.Relations {
+		if relation.OwnField && generated[relation.TableIdentity] {
+			result.Relations = append(result.Relations, relation)
+		}
+	}
+	return result
+}
@@ -1,16 +1,12 @@
-package main
+package entityRender
 
 import (
 	"bytes"
 	"fmt"
-	"io"
-	"log"
-	"os"
 	"time"
-	"tnd/work/generateJavaEntity/cascadeMapping"
 )
 
-func generateDto(table TableWithRelation) error {
+func generateDto(table TableWithRelation, packageName string) (Artifact, error) {
 	restServiceTemplate, err := createTemplate("Dto").Parse(`// generated at {{.Time}}
 {{- with .Package}}
 package {{.}}.restservice;
@@ -19,12 +15,12 @@ public class {{.Table.TypeName}}Dto {
 	{{- range .Table.BasicColumns}}
 		{{- if index $.Table.PrimaryKeys .Name}}
 		{{- else}}
-	private {{.Type | javaType}} {{.Name | camelCase}};
+	private {{.JavaType}} {{.Name | camelCase}};
 		{{- end}}
 	{{- end}}
 
 	{{- range .Table.Relations}}
-		{{- if call $.IsCascade $.Table.TableIdentity .TableIdentity}}
+		{{- if .Cascade}}
 			{{- if .ToMany}}
 	private List<{{.TypeName}}Dto> {{.FieldName | pluralName}};
 			{{- else}}
@@ -44,17 +40,17 @@ public class {{.Table.TypeName}}Dto {
 	{{- range .Table.BasicColumns}}
 		{{- if index $.Table.PrimaryKeys .Name}}
 		{{- else}}
-	public {{.Type | javaType}} get{{.Name | camelCase | firstToUpper}}() {
+	public {{.JavaType}} get{{.Name | camelCase | firstToUpper}}() {
 		return {{.Name | camelCase}};
 	}
-	public void set{{.Name | camelCase | firstToUpper}}({{.Type | javaType}} {{.Name | camelCase}}) {
+	public void set{{.Name | camelCase | firstToUpper}}({{.JavaType}} {{.Name | camelCase}}) {
 		this.{{.Name | camelCase}} = {{.Name | camelCase}};
 	}
 		{{- end}}
 	{{- end}}
 
 	{{- range .Table.Relations}}
-		{{- if call $.IsCascade $.Table.TableIdentity .TableIdentity}}
+		{{- if .Cascade}}
 			{{- if .ToMany}}
 	public List<{{.TypeName}}Dto> get{{.FieldName | pluralName | firstToUpper}}() {
 		return {{.FieldName | pluralName}};
@@ -93,30 +89,16 @@ public class {{.Table.TypeName}}Dto {
 }
 `)
 	if err != nil {
-		return fmt.Errorf("template parse: %w", err)
+		return Artifact{}, fmt.Errorf("template parse: %w", err)
 	}
-	var resultWriter io.Writer
-	if *generateFile {
-		fileName := "generated/restservice/" + table.TypeName + "Dto.java"
-		os.MkdirAll("generated/restservice", 0755)
-		file, err := os.Create(fileName)
-		if err != nil {
-			return fmt.Errorf("os create %v: %w", fileName, err)
-		}
-		defer file.Close()
-		resultWriter = file
-	} else {
-		buffer := new(bytes.Buffer)
-		defer func() {
-			log.Println(buffer.String())
-		}()
-		resultWriter = buffer
-	}
-	err = restServiceTemplate.Execute(resultWriter, map[string]interface{}{
-		"Time":      time.Now(),
-		"Table":     table,
-		"Package":   *packageName,
-		"IsCascade": cascadeMapping.IsCascadeRelation,
+	buffer := new(bytes.Buffer)
+	err = restServiceTemplate.Execute(buffer, map[string]interface{}{
+		"Time":    time.Now(),
+		"Table":   table,
+		"Package": packageName,
 	})
-	return err
+	if err != nil {
+		return Artifact{}, fmt.Errorf("template execute: %w", err)
+	}
+	return Artifact{Path: "restservice/" + table.TypeName + "Dto.java", Content: buffer.Bytes()}, nil
 }
@@ -0,0 +1,219 @@
+package entityRender
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+const javaEntityTemplateText = `// generated at {{.Time}}
+{{- with .Package}}
+package {{.}}.entity;
+{{end}}
+import java.io.Serializable;
+
+import java.math.BigDecimal;
+
+import javax.persistence.CascadeType;
+import javax.persistence.Column;
+import javax.persistence.Convert;
+import javax.persistence.EmbeddedId;
+import javax.persistence.Entity;
+import javax.persistence.FetchType;
+import javax.persistence.GeneratedValue;
+import javax.persistence.GenerationType;
+import javax.persistence.Id;
+import javax.persistence.JoinColumn;
+import javax.persistence.JoinColumns;
+import javax.persistence.JoinTable;
+import javax.persistence.ManyToMany;
+import javax.persistence.ManyToOne;
+import javax.persistence.MapsId;
+import javax.persistence.OneToMany;
+import javax.persistence.OneToOne;
+import javax.persistence.SequenceGenerator;
+import javax.persistence.Table;
+import javax.persistence.Transient;
+
+import java.time.LocalDate;
+import java.time.LocalDateTime;
+{{- range .Imports}}
+import {{.}};
+{{- end}}
+
+import java.util.List;
+import java.util.ArrayList;
+
+import th.go.cgd.ip.shared.entity.AuditData;
+{{if .Table.Stub}}
+/** Reference stub of {{.Table.Name}}, mapping only what the generated entities refer to. */
+{{- end}}
+@Entity
+@Table(schema = "{{.Table.Schema}}", name="{{.Table.Name}}")
+public class {{.Table.Name | camelCase | firstToUpper }} {{if .Table.Audited}}extends AuditData {{end}} implements Serializable {
+
+	private static final long serialVersionUID = 1L;
+	{{- if .Table.CompositeKey}}
+
+	@EmbeddedId
+	private {{.Table.IdType}} id = new {{.Table.IdType}}();
+	{{- end}}
+
+	{{range .Table.BasicColumns}}
+		{{- with .Remarks}}
+	/** {{javadoc .}} */
+		{{- end}}
+		{{- if isId .Name}}
+	@Id
+			{{- if .Identity}}
+	@GeneratedValue(strategy = GenerationType.IDENTITY)
+			{{- else if $.Table.NoSeq}}
+			{{- else}}
+				{{- with sequenceName .Name}}
+	@GeneratedValue(strategy = GenerationType.SEQUENCE, generator = "{{.}}")
+	@SequenceGenerator(schema="{{$.Table.Schema}}", name="{{.}}", sequenceName="{{.}}", initialValue = 1, allocationSize = 1)
+				{{- end}}
+			{{- end}}
+		{{- end}}
+		{{- with .Converter}}
+	@Convert(converter={{.}}.class)
+		{{- end}}
+	@Column(name="{{.Name}}"{{. | colSpec}}) // Database's type is {{.Type}}{{with .Default}}, default {{.}}{{end}}
+	private {{.JavaType}} {{.Name | camelCase}};
+	{{end}}
+	{{- range .Table.Relations}}
+		{{range .Annotation}}
+	{{.}}
+		{{- end}}
+		{{- if .ToMany}}
+	private List<{{.TypeName}}> {{.FieldName | pluralName}} = new ArrayList<>();
+		{{- else}}
+	private {{.TypeName}} {{.FieldName}};
+		{{- end}}
+	{{end}}
+
+	{{- if .Table.CompositeKey}}
+	public {{.Table.IdType}} getId() {
+		return id;
+	}
+	public void setId({{.Table.IdType}} id) {
+		this.id = id;
+	}
+	{{end}}
+
+	{{- range .Table.BasicColumns}}	
+	public {{.JavaType}} get{{.Name | camelCase | firstToUpper}}() {
+		return {{.Name | camelCase}};
+	}
+	public void set{{.Name | camelCase | firstToUpper}}({{.JavaType}} {{.Name | camelCase}}) {
+		this.{{.Name | camelCase}} = {{.Name | camelCase}};
+	}
+	{{end}}
+	{{- range .Table.Relations}}
+		{{- if .ToMany}}
+	public List<{{.TypeName}}> get{{.FieldName | pluralName | firstToUpper}}() {
+		return {{.FieldName | pluralName}};
+	}
+	public void set{{.FieldName | pluralName | firstToUpper}}(List<{{.TypeName}}> {{.FieldName | pluralName}}) {
+		this.{{.FieldName | pluralName}} = {{.FieldName | pluralName}};
+	}
+		{{- else}}
+	public {{.TypeName}} get{{.FieldName | firstToUpper}}() {
+		return {{.FieldName}};
+	}
+	public void set{{.FieldName | firstToUpper}}({{.TypeName}} {{.FieldName}}) {
+		this.{{.FieldName}} = {{.FieldName}};
+	}
+		{{- end}}
+	{{end}}
+}
+`
+
+type javaEntityTemplateContext struct {
+	Table      TableWithRelation
+	Imports    []string
+	EntityName string
+	Time       time.Time
+	Package    string
+}
+
+func generateJavaEntityByDefinition(table TableWithRelation, packageName string) (Artifact, error) {
+	javaEntityTemplate, err := createTemplate("entity").Funcs(map[string]interface{}{
+		"isId": func(colName string) bool {
+			return table.PrimaryKeys[colName]
+		},
+		"sequenceName": func(colName string) string {
+			if len(table.PrimaryKeys) == 1 {
+				// if colName == "ID" || colName == table.Name+"_ID" {
+				return table.Name + "_SEQ"
+				// }
+			}
+			return ""
+		},
+		"colSpec": func(col ColumnWithType) string {
+			return columnSpec(table, col)
+		},
+	}).Parse(javaEntityTemplateText)
+	if err != nil {
+		return Artifact{}, fmt.Errorf("text template parse: %w", err)
+	}
+	imports := columnImports(table.BasicColumns)
+	var entityName string = table.TypeName
+	buffer := new(bytes.Buffer)
+	err = javaEntityTemplate.Execute(buffer, javaEntityTemplateContext{
+		Table:   table,
+		Imports: imports,
+		Time:    time.Now(),
+		Package: packageName,
+	})
+	if err != nil {
+		return Artifact{}, fmt.Errorf("template ExecuteL: %w", err)
+	}
+	return Artifact{Path: "entity/" + entityName + ".java", Content: buffer.Bytes()}, nil
+}
+
+func generateJavaRepository(table TableWithRelation, packageName string) (Artifact, error) {
+	repoTemplate, err := template.New("repo").Parse(`// generated at {{.Time}}
+{{- with .Package}}
+package {{.}}.repository;
+{{end}}
+ 
+import org.springframework.data.jpa.repository.JpaRepository;
+import org.springframework.data.jpa.repository.JpaSpecificationExecutor;
+{{- with .IdTypeImport}}
+import {{.}};
+{{- end}}
+{{- with .Package}}
+
+import {{.}}.entity.{{$.EntityTypeName}};
+{{- if $.CompositeKey}}
+import {{.}}.entity.{{$.PrimaryKeyTypeName}};
+{{- end}}
+{{- end}}
+
+public interface {{.EntityTypeName}}Repository extends JpaRepository<{{.EntityTypeName}},{{.PrimaryKeyTypeName}}>, JpaSpecificationExecutor<{{.EntityTypeName}}> {
+
+}
+`)
+	if err != nil {
+		return Artifact{}, fmt.Errorf("template parse: %w", err)
+	}
+	primaryKeyTypeName := table.IdType
+	if primaryKeyTypeName == "" {
+		return Artifact{}, nil
+	}
+	buffer := new(bytes.Buffer)
+	err = repoTemplate.Execute(buffer, map[string]interface{}{
+		"Time":               time.Now(),
+		"Package":            packageName,
+		"EntityTypeName":     table.TypeName,
+		"PrimaryKeyTypeName": primaryKeyTypeName,
+		"CompositeKey":       table.CompositeKey,
+		"IdTypeImport":       idTypeImport(table),
+	})
+	if err != nil {
+		return Artifact{}, fmt.Errorf("template execute: %w", err)
+	}
+	return Artifact{Path: "repository/" + table.TypeName + "Repository.java", Content: buffer.Bytes()}, nil
+}
@@ -0,0 +1,88 @@
+package entityRender
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+func generateJavaEntityKey(table TableWithRelation, packageName string) (Artifact, error) {
+	keyTemplate, err := createTemplate("Key").Funcs(map[string]interface{}{
+		"colSpec": func(col ColumnWithType) string {
+			return columnSpec(table, col)
+		},
+	}).Parse(`// generated at {{.Time}}
+{{- with .Package}}
+package {{.}}.entity;
+{{end}}
+import java.io.Serializable;
+
+import java.math.BigDecimal;
+
+import javax.persistence.Column;
+import javax.persistence.Convert;
+import javax.persistence.Embeddable;
+
+import java.time.LocalDate;
+import java.time.LocalDateTime;
+{{- range .Imports}}
+import {{.}};
+{{- end}}
+
+import java.util.Objects;
+
+@Embeddable
+public class {{.Table.IdType}} implements Serializable {
+
+	private static final long serialVersionUID = 1L;
+
+	{{range .Table.IdColumns}}
+		{{- with .Converter}}
+	@Convert(converter={{.}}.class)
+		{{- end}}
+	@Column(name="{{.Name}}"{{. | colSpec}}) // Database's type is {{.Type}}
+	private {{.JavaType}} {{.Name | camelCase}};
+	{{end}}
+
+	{{- range .Table.IdColumns}}
+	public {{.JavaType}} get{{.Name | camelCase | firstToUpper}}() {
+		return {{.Name | camelCase}};
+	}
+	public void set{{.Name | camelCase | firstToUpper}}({{.JavaType}} {{.Name | camelCase}}) {
+		this.{{.Name | camelCase}} = {{.Name | camelCase}};
+	}
+	{{end}}
+	@Override
+	public boolean equals(Object o) {
+		if (this == o) {
+			return true;
+		}
+		if (o == null || getClass() != o.getClass()) {
+			return false;
+		}
+		{{.Table.IdType}} other = ({{.Table.IdType}}) o;
+		return {{range $i, $col := .Table.IdColumns}}{{if $i}}
+			&& {{end}}Objects.equals({{$col.Name | camelCase}}, other.{{$col.Name | camelCase}}){{end}};
+	}
+
+	@Override
+	public int hashCode() {
+		return Objects.hash({{range $i, $col := .Table.IdColumns}}{{if $i}}, {{end}}{{$col.Name | camelCase}}{{end}});
+	}
+}
+`)
+	if err != nil {
+		return Artifact{}, fmt.Errorf("template parse: %w", err)
+	}
+	buffer := new(bytes.Buffer)
+	err = keyTemplate.Execute(buffer, map[string]interface{}{
+		"Time":    time.Now(),
+		"Table":   table,
+		"Imports": columnImports(table.IdColumns),
+		"Package": packageName,
+	})
+	if err != nil {
+		return Artifact{}, fmt.Errorf("template execute: %w", err)
+	}
+	return Artifact{Path: "entity/" + table.IdType + ".java", Content: buffer.Bytes()}, nil
+}
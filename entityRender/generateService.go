@@ -1,17 +1,14 @@
-package main
+package entityRender
 
 import (
 	"bytes"
 	"fmt"
-	"io"
-	"log"
-	"os"
 	"time"
 )
 
-func generateJavaRestService(table TableWithRelation) error {
+func generateJavaRestService(table TableWithRelation, packageName string) (Artifact, error) {
 	if table.IdType == "" {
-		return nil
+		return Artifact{}, nil
 	}
 	restServiceTemplate, err := createTemplate("RestService").Parse(`// generated at {{.Time}}
 {{- with .Package}}
@@ -21,6 +18,9 @@ package {{.}}.restservice;
 import java.util.List;
 import java.util.Map;
 import java.util.Optional;
+{{- with .IdTypeImport}}
+import {{.}};
+{{- end}}
 
 import org.modelmapper.ModelMapper;
 import org.modelmapper.convention.MatchingStrategies;
@@ -32,6 +32,9 @@ import com.fasterxml.jackson.databind.JsonNode;
 
 {{- with .Package}}
 import {{.}}.entity.{{$.Table.TypeName}};
+{{- if $.Table.CompositeKey}}
+import {{.}}.entity.{{$.Table.IdType}};
+{{- end}}
 import {{.}}.repository.{{$.Table.TypeName}}Repository;
 {{- end}}
 import th.go.cgd.ip.shared.api.RequestContext;
@@ -74,7 +77,7 @@ public class {{.Table.TypeName}}RestService implements RestfulService<{{.Table.I
 	
 	@Override
 	@Transactional
-	public Long post(RequestContext<Map<String, List<String>>> context, {{.Table.TypeName}}Dto model) throws Exception {
+	public {{.Table.IdType}} post(RequestContext<Map<String, List<String>>> context, {{.Table.TypeName}}Dto model) throws Exception {
 		{{.Table.TypeName}} entity = new {{.Table.TypeName}}();
 		dtoToEntityPipeEntityManagerPersist(model, entity);
 		return entity.get{{.Table.IdField | firstToUpper}}();
@@ -125,29 +128,17 @@ public class {{.Table.TypeName}}RestService implements RestfulService<{{.Table.I
 }
 `)
 	if err != nil {
-		return fmt.Errorf("template parse: %w", err)
-	}
-	var resultWriter io.Writer
-	if *generateFile {
-		fileName := "generated/restservice/" + table.TypeName + "RestService.java"
-		os.MkdirAll("generated/restservice", 0755)
-		file, err := os.Create(fileName)
-		if err != nil {
-			return fmt.Errorf("os create %v: %w", fileName, err)
-		}
-		defer file.Close()
-		resultWriter = file
-	} else {
-		buffer := new(bytes.Buffer)
-		defer func() {
-			log.Println(buffer.String())
-		}()
-		resultWriter = buffer
+		return Artifact{}, fmt.Errorf("template parse: %w", err)
 	}
-	err = restServiceTemplate.Execute(resultWriter, map[string]interface{}{
-		"Time":    time.Now(),
-		"Table":   table,
-		"Package": *packageName,
+	buffer := new(bytes.Buffer)
+	err = restServiceTemplate.Execute(buffer, map[string]interface{}{
+		"Time":         time.Now(),
+		"Table":        table,
+		"Package":      packageName,
+		"IdTypeImport": idTypeImport(table),
 	})
-	return err
+	if err != nil {
+		return Artifact{}, fmt.Errorf("template execute: %w", err)
+	}
+	return Artifact{Path: "restservice/" + table.TypeName + "RestService.java", Content: buffer.Bytes()}, nil
 }
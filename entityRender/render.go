@@ -0,0 +1,158 @@
+// Package entityRender renders the Java sources of the entities of entityModel.
+package entityRender
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"tnd/work/generateJavaEntity/entityModel"
+)
+
+type TableWithRelation = entityModel.TableWithRelation
+type ColumnWithType = entityModel.ColumnWithType
+
+// Artifact is a rendered source file. Path is relative to the output directory,
+// with slashes as separators.
+type Artifact struct {
+	Path    string
+	Content []byte
+}
+
+func createTemplate(name string) *template.Template {
+	return template.New(name).Funcs(map[string]interface{}{
+		"camelCase":    entityModel.CamelCase,
+		"firstToUpper": strings.Title,
+		"firstToLower": func(name string) string {
+			return strings.ToLower(name[:1]) + name[1:]
+		},
+		"camelToHyphen": entityModel.CamelToHyphen,
+		"toLower":       strings.ToLower,
+		"pluralName":    entityModel.PluralName,
+		"javadoc": func(text string) string {
+			return strings.Replace(text, "*/", "*&#47;", -1)
+		},
+	})
+}
+
+// columnSpec returns the extra attributes of the @Column annotation of col.
+func columnSpec(table TableWithRelation, col ColumnWithType) string {
+	var spec string
+	switch {
+	case col.ColumnDefinition != "":
+		spec = fmt.Sprint(`, columnDefinition="`, col.ColumnDefinition, `"`)
+	case col.Type == "VARGRAPHIC", col.Type == "GRAPHIC":
+		spec = fmt.Sprint(`, columnDefinition="`, col.Type, `(`, col.Length, `)"`)
+	case col.Type == "CHARACTER":
+		spec = fmt.Sprint(`, columnDefinition="CHAR(`, col.Length, `)"`)
+	case col.Type == "VARCHAR":
+		spec = fmt.Sprint(`, length=`, col.Length)
+	case col.Type == "DECIMAL":
+		spec = fmt.Sprint(`, precision=`, col.Length, `, scale=`, col.Scale)
+	}
+	if !col.Nullable && !table.PrimaryKeys[col.Name] {
+		spec += `, nullable=false`
+	}
+	if col.Generated {
+		spec += `, insertable=false, updatable=false`
+	}
+	return spec
+}
+
+// columnImports lists the sorted imports needed by the Java types of columns.
+func columnImports(columns []ColumnWithType) []string {
+	var imports []string
+	importSet := make(map[string]bool)
+	for _, col := range columns {
+		colImports := col.Imports
+		if imp, ok := javaTypeImports[col.JavaType]; ok {
+			colImports = append(colImports, imp)
+		}
+		for _, imp := range colImports {
+			if !importSet[imp] {
+				importSet[imp] = true
+				imports = append(imports, imp)
+			}
+		}
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// entityTemplateImports lists the imports of the Java types that the entity template imports itself.
+var entityTemplateImports = map[string]string{
+	"BigDecimal":    "java.math.BigDecimal",
+	"LocalDate":     "java.time.LocalDate",
+	"LocalDateTime": "java.time.LocalDateTime",
+}
+
+// idTypeImport returns the import of the Java type of a single column primary key,
+// for the repository and RestService declaring it outside of the entity. It is
+// empty for the composite keys and for the types of java.lang.
+func idTypeImport(table TableWithRelation) string {
+	if table.CompositeKey {
+		return ""
+	}
+	for _, col := range table.BasicColumns {
+		if !table.PrimaryKeys[col.Name] {
+			continue
+		}
+		// a type mapping with a fully qualified javaType imports it
+		for _, imp := range col.Imports {
+			if strings.HasSuffix(imp, "."+col.JavaType) {
+				return imp
+			}
+		}
+		if imp, ok := javaTypeImports[col.JavaType]; ok {
+			return imp
+		}
+		return entityTemplateImports[col.JavaType]
+	}
+	return ""
+}
+
+// Render renders the entity of table with its key class, repository, DTO and
+// RestService in packageName, which may be empty. Reference stubs get only their
+// entity and key class.
+func Render(table TableWithRelation, packageName string) ([]Artifact, error) {
+	var result []Artifact
+	render := func(name string, generator func(TableWithRelation, string) (Artifact, error)) error {
+		a, err := generator(table, packageName)
+		if err != nil {
+			return fmt.Errorf("%v %v.%v: %w", name, table.Schema, table.Name, err)
+		}
+		if a.Path != "" {
+			result = append(result, a)
+		}
+		return nil
+	}
+	if err := render("generate by definition", generateJavaEntityByDefinition); err != nil {
+		return nil, err
+	}
+	if table.CompositeKey {
+		if err := render("generateJavaEntityKey", generateJavaEntityKey); err != nil {
+			return nil, err
+		}
+	}
+	if table.Stub {
+		return result, nil
+	}
+	if err := render("generateJavaRepository", generateJavaRepository); err != nil {
+		return nil, err
+	}
+	if err := render("generateDto", generateDto); err != nil {
+		return nil, err
+	}
+	if err := render("generateJavaRestService", generateJavaRestService); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// javaTypeImports lists the imports needed by Java types that the entity template does not import already.
+var javaTypeImports = map[string]string{
+	"LocalTime":      "java.time.LocalTime",
+	"OffsetDateTime": "java.time.OffsetDateTime",
+	"OffsetTime":     "java.time.OffsetTime",
+	"UUID":           "java.util.UUID",
+}
@@ -0,0 +1,59 @@
+package entityRender
+
+import (
+	"strings"
+	"testing"
+	"tnd/work/generateJavaEntity/entityModel"
+)
+
+func TestRenderIdTypeImport(t *testing.T) {
+	tests := []struct {
+		column     ColumnWithType
+		wantImport string
+	}{
+		{ColumnWithType{ColumnDef: entityModel.ColumnDef{Name: "ID", Type: "UUID"}, JavaType: "UUID"}, "java.util.UUID"},
+		{ColumnWithType{ColumnDef: entityModel.ColumnDef{Name: "ID", Type: "DECIMAL", Length: 9}, JavaType: "BigDecimal"}, "java.math.BigDecimal"},
+		{ColumnWithType{
+			ColumnDef: entityModel.ColumnDef{Name: "ID", Type: "CHARACTER", Length: 12},
+			JavaType:  "AccountNumber",
+			Converter: "AccountNumberConverter",
+			Imports:   []string{"com.example.type.AccountNumber", "com.example.type.AccountNumberConverter"},
+		}, "com.example.type.AccountNumber"},
+		{ColumnWithType{ColumnDef: entityModel.ColumnDef{Name: "ID", Type: "BIGINT"}, JavaType: "Long"}, ""},
+	}
+	for _, test := range tests {
+		table := TableWithRelation{
+			TableIdentity: entityModel.TableIdentity{Schema: "ONLDB", Name: "ACCOUNT"},
+			TypeName:      "Account",
+			IdType:        test.column.JavaType,
+			IdField:       "id",
+			PrimaryKeys:   map[string]bool{"ID": true},
+			BasicColumns:  []ColumnWithType{test.column},
+		}
+		artifacts, err := Render(table, "com.example")
+		if err != nil {
+			t.Fatalf("Render %v: %v", test.column.JavaType, err)
+		}
+		for _, a := range artifacts {
+			if a.Path != "repository/AccountRepository.java" && a.Path != "restservice/AccountRestService.java" {
+				continue
+			}
+			imports := 0
+			for _, line := range strings.Split(string(a.Content), "\n") {
+				if strings.HasPrefix(line, "import ") && strings.HasSuffix(line, "."+test.column.JavaType+";") {
+					imports++
+					if line != "import "+test.wantImport+";" {
+						t.Errorf("%v: %v, want import %v", a.Path, line, test.wantImport)
+					}
+				}
+			}
+			wantImports := 0
+			if test.wantImport != "" {
+				wantImports = 1
+			}
+			if imports != wantImports {
+				t.Errorf("%v: %v imports of %v, want import %q", a.Path, imports, test.column.JavaType, test.wantImport)
+			}
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Package generator generates the Java sources of the entities of a schema.
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"tnd/work/generateJavaEntity/artifactSink"
+	"tnd/work/generateJavaEntity/entityModel"
+	"tnd/work/generateJavaEntity/entityRender"
+	"tnd/work/generateJavaEntity/tableDefinition"
+)
+
+type SchemaProvider = tableDefinition.SchemaProvider
+type TableIdentity = tableDefinition.TableIdentity
+type TableWithRelation = entityModel.TableWithRelation
+
+// Options configure a Generator.
+type Options struct {
+	// Package is the Java package of the generated sources, none when empty.
+	Package string
+	// Primitives maps NOT NULL columns to primitive Java types.
+	Primitives bool
+	// Config is compiled, as ReadConfig returns it or by Config.Compile.
+	Config entityModel.Config
+	// CascadeByDeleteRule derives cascades from ON DELETE CASCADE foreign keys
+	// instead of the cascade rules of Config.
+	CascadeByDeleteRule bool
+	// Depth also generates the tables related to the selected ones this many
+	// relations deep, for every selected table.
+	Depth int
+	// Stubs renders reference stubs for the related tables left out by the
+	// selection and Depth, so that both sides of every relationship exist.
+	Stubs bool
+	// Parallel is the number of tables read and rendered at the same time.
+	Parallel int
+	// SourceLayout places the artifacts under src/main/java/<Package path>, the
+	// Maven and Gradle source layout, instead of directly in the output root.
+	SourceLayout bool
+}
+
+// Generator generates the entities of the tables of a schema.
+type Generator struct {
+	schema   SchemaProvider
+	options  Options
+	analyzer entityModel.Analyzer
+
+	warningsLock sync.Mutex
+	warnings     []string
+}
+
+// New returns a Generator of the tables of schema. The configuration of options
+// must be compiled.
+func New(schema SchemaProvider, options Options) (*Generator, error) {
+	if !options.Config.Compiled() {
+		return nil, errors.New("configuration is not compiled, see Config.Compile")
+	}
+	g := &Generator{schema: schema, options: options}
+	g.analyzer = entityModel.Analyzer{
+		Schema:              schema,
+		Config:              options.Config,
+		Primitives:          options.Primitives,
+		CascadeByDeleteRule: options.CascadeByDeleteRule,
+		Warn:                g.warnf,
+	}
+	return g, nil
+}
+
+func (g *Generator) warnf(format string, a ...interface{}) {
+	warning := fmt.Sprintf(format, a...)
+	g.warningsLock.Lock()
+	defer g.warningsLock.Unlock()
+	for _, w := range g.warnings {
+		if w == warning {
+			return
+		}
+	}
+	g.warnings = append(g.warnings, warning)
+}
+
+// Warnings returns the sorted problems that did not stop the generation.
+func (g *Generator) Warnings() []string {
+	g.warningsLock.Lock()
+	defer g.warningsLock.Unlock()
+	warnings := append([]string(nil), g.warnings...)
+	sort.Strings(warnings)
+	return warnings
+}
+
+// Generate renders the artifacts of tables and of the tables their relations cascade to.
+// However many tables are given, Depth follows the other relations and Stubs adds
+// reference stubs for the related tables left out. Tables are read and rendered on
+// Parallel goroutines; a failing table doesn't stop the others, the artifacts of
+// the tables that succeeded are returned with the errors.
+func (g *Generator) Generate(tables []TableIdentity) ([]entityRender.Artifact, error) {
+	var errs errorList
+	var tableWithRelationList []TableWithRelation
+	tableWithRelationMap := make(map[TableIdentity]bool)
+	joinTables := make(map[TableIdentity]bool)
+	var wave []TableIdentity
+	var waveLevels []int
+	addTable := func(table TableIdentity, level int) {
+		if !tableWithRelationMap[table] && !joinTables[table] {
+			tableWithRelationMap[table] = true
+			wave = append(wave, table)
+			waveLevels = append(waveLevels, level)
+		}
+	}
+	for _, table := range tables {
+		addTable(table, 0)
+	}
+	// every wave reads the tables found by the relations of the previous one
+	var referenced []TableIdentity
+	for len(wave) > 0 {
+		current, currentLevels := wave, waveLevels
+		wave, waveLevels = nil, nil
+		results, fetchErrs := g.fetchTableRelations(current)
+		for i, tableWithRelation := range results {
+			if errors.Is(fetchErrs[i], errJoinTable) {
+				delete(tableWithRelationMap, current[i])
+				joinTables[current[i]] = true
+				g.warnf("table %v.%v is a join table mapped by @ManyToMany, no entity is generated for it", current[i].Schema, current[i].Name)
+				continue
+			}
+			if fetchErrs[i] != nil {
+				errs = append(errs, fetchErrs[i])
+				continue
+			}
+			tableWithRelationList = append(tableWithRelationList, tableWithRelation)
+			for _, relation := range tableWithRelation.Relations {
+				switch {
+				case !g.options.Config.TableAllowed(relation.TableIdentity):
+				case relation.Cascade:
+					addTable(relation.TableIdentity, currentLevels[i])
+				case currentLevels[i] < g.options.Depth:
+					addTable(relation.TableIdentity, currentLevels[i]+1)
+				default:
+					referenced = append(referenced, relation.TableIdentity)
+				}
+			}
+		}
+	}
+	if g.options.Stubs {
+		var stubTables []TableIdentity
+		stubMap := make(map[TableIdentity]bool)
+		for _, table := range referenced {
+			if !tableWithRelationMap[table] && !joinTables[table] && !stubMap[table] {
+				stubMap[table] = true
+				stubTables = append(stubTables, table)
+			}
+		}
+		results, fetchErrs := g.fetchTableRelations(stubTables)
+		for i, tableWithRelation := range results {
+			if errors.Is(fetchErrs[i], errJoinTable) {
+				continue
+			}
+			if fetchErrs[i] != nil {
+				errs = append(errs, fetchErrs[i])
+				continue
+			}
+			tableWithRelationList = append(tableWithRelationList, entityModel.ReferenceStub(tableWithRelation, tableWithRelationMap))
+		}
+	}
+
+	artifacts := make([][]entityRender.Artifact, len(tableWithRelationList))
+	renderErrs := make([]error, len(tableWithRelationList))
+	g.parallel(len(tableWithRelationList), func(i int) {
+		artifacts[i], renderErrs[i] = entityRender.Render(tableWithRelationList[i], g.options.Package)
+	})
+	var result []entityRender.Artifact
+	for i, tableArtifacts := range artifacts {
+		if renderErrs[i] != nil {
+			errs = append(errs, renderErrs[i])
+			continue
+		}
+		for _, a := range tableArtifacts {
+			if g.options.SourceLayout {
+				a.Path = sourcePath(g.options.Package, a.Path)
+			}
+			result = append(result, a)
+		}
+	}
+	return result, errs.err()
+}
+
+// sourcePath returns the path of an artifact of packageName in the Maven and Gradle source layout.
+func sourcePath(packageName string, artifactPath string) string {
+	return path.Join("src/main/java", strings.Replace(packageName, ".", "/", -1), artifactPath)
+}
+
+// GenerateTo generates tables like Generate and writes the artifacts to sink, in order.
+// The artifacts of the tables that succeeded are written even when others failed.
+func (g *Generator) GenerateTo(sink artifactSink.Sink, tables []TableIdentity) error {
+	artifacts, err := g.Generate(tables)
+	var errs errorList
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, a := range artifacts {
+		if err := sink.Write(a.Path, a.Content); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs.err()
+}
+
+// errJoinTable is the error of fetchTableRelations for the plain join tables, which
+// have no entity of their own.
+var errJoinTable = errors.New("plain join table")
+
+// fetchTableRelations reads tables in parallel. The results and errors are in the order of tables.
+func (g *Generator) fetchTableRelations(tables []TableIdentity) ([]TableWithRelation, []error) {
+	results := make([]TableWithRelation, len(tables))
+	errs := make([]error, len(tables))
+	g.parallel(len(tables), func(i int) {
+		tableDef, err := tableDefinition.GetTableDef(g.schema, tables[i])
+		if err != nil {
+			errs[i] = fmt.Errorf("get table def %v.%v: %w", tables[i].Schema, tables[i].Name, err)
+			return
+		}
+		if entityModel.IsTableManyToManyRelation(g.options.Config.FilterTableDef(tableDef)) {
+			errs[i] = errJoinTable
+			return
+		}
+		results[i], err = g.analyzer.GetTableRelation(tableDef)
+		if err != nil {
+			errs[i] = fmt.Errorf("get table relation %v.%v: %w", tables[i].Schema, tables[i].Name, err)
+		}
+	})
+	return results, errs
+}
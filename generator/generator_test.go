@@ -0,0 +1,208 @@
+package generator_test
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"tnd/work/generateJavaEntity/artifactSink"
+	"tnd/work/generateJavaEntity/entityModel"
+	"tnd/work/generateJavaEntity/generator"
+	"tnd/work/generateJavaEntity/schemaSource"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// testSchema has a plain join table, an association table with a payload, a
+// self reference, two foreign keys to the same table and a child named after its
+// parent.
+var testSchema = []string{
+	`CREATE TABLE STUDENT (ID INTEGER NOT NULL PRIMARY KEY, NAME VARCHAR(20))`,
+	`CREATE TABLE COURSE (ID INTEGER NOT NULL PRIMARY KEY, TITLE VARCHAR(20))`,
+	`CREATE TABLE STUDENT_COURSE (
+		STUDENT_ID INTEGER NOT NULL REFERENCES STUDENT,
+		COURSE_ID INTEGER NOT NULL REFERENCES COURSE,
+		PRIMARY KEY (STUDENT_ID, COURSE_ID))`,
+	`CREATE TABLE EXAM (ID INTEGER NOT NULL PRIMARY KEY)`,
+	`CREATE TABLE EXAM_RESULT (
+		EXAM_ID INTEGER NOT NULL REFERENCES EXAM,
+		STUDENT_ID INTEGER NOT NULL REFERENCES STUDENT,
+		SCORE DECIMAL(5,2),
+		PRIMARY KEY (EXAM_ID, STUDENT_ID))`,
+	`CREATE TABLE ORG_UNIT (ID INTEGER NOT NULL PRIMARY KEY, PARENT_ID INTEGER REFERENCES ORG_UNIT, NAME VARCHAR(20))`,
+	`CREATE TABLE REQUEST (
+		ID INTEGER NOT NULL PRIMARY KEY,
+		REQUESTER_ID INTEGER REFERENCES STUDENT,
+		APPROVER_ID INTEGER REFERENCES STUDENT)`,
+}
+
+func openTestSchema(t *testing.T) generator.SchemaProvider {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "generator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	for _, statement := range testSchema {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("%v: %v", statement, err)
+		}
+	}
+	schema, err := schemaSource.NewSchema("sqlite", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func generateTestSchema(t *testing.T, options generator.Options, pattern string) (artifactSink.Memory, []string) {
+	t.Helper()
+	g, err := generator.New(openTestSchema(t), options)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	tables, err := g.SelectTables("MAIN", pattern)
+	if err != nil {
+		t.Fatalf("SelectTables: %v", err)
+	}
+	sink := artifactSink.Memory{}
+	if err := g.GenerateTo(sink, tables); err != nil {
+		t.Fatalf("GenerateTo: %v", err)
+	}
+	return sink, g.Warnings()
+}
+
+// assertSource checks that the artifact at path holds every snippet, comparing
+// them with the runs of white space collapsed.
+func assertSource(t *testing.T, sink artifactSink.Memory, path string, snippets ...string) {
+	t.Helper()
+	content, ok := sink[path]
+	if !ok {
+		t.Errorf("%v not generated", path)
+		return
+	}
+	source := strings.Join(strings.Fields(string(content)), " ")
+	for _, snippet := range snippets {
+		if !strings.Contains(source, snippet) {
+			t.Errorf("%v has no %q:\n%s", path, snippet, content)
+		}
+	}
+}
+
+func TestGenerateRelations(t *testing.T) {
+	sink, warnings := generateTestSchema(t, generator.Options{Package: "com.example", Parallel: 2}, "*")
+
+	var entities []string
+	for path := range sink {
+		if strings.HasPrefix(path, "entity/") {
+			entities = append(entities, path)
+		}
+	}
+	sort.Strings(entities)
+	want := []string{
+		"entity/Course.java",
+		"entity/Exam.java",
+		"entity/ExamResult.java",
+		"entity/ExamResultId.java",
+		"entity/OrgUnit.java",
+		"entity/Request.java",
+		"entity/Student.java",
+	}
+	if strings.Join(entities, " ") != strings.Join(want, " ") {
+		t.Errorf("entities %v, want %v", entities, want)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("warnings %q", warnings)
+	}
+
+	// STUDENT_COURSE is mapped by @ManyToMany, owned by STUDENT whose name prefixes it
+	assertSource(t, sink, "entity/Student.java",
+		`@ManyToMany(fetch=FetchType.LAZY) @JoinTable(name="STUDENT_COURSE", schema="MAIN", `+
+			`joinColumns={@JoinColumn(name="STUDENT_ID")}, inverseJoinColumns={@JoinColumn(name="COURSE_ID")} ) `+
+			`private List<Course> courses = new ArrayList<>();`,
+		`@OneToMany(fetch=FetchType.LAZY, mappedBy="requester") private List<Request> requesterRequests`,
+		`@OneToMany(fetch=FetchType.LAZY, mappedBy="approver") private List<Request> approverRequests`,
+		`@OneToMany(fetch=FetchType.LAZY, mappedBy="student") private List<ExamResult> examResults`,
+	)
+	assertSource(t, sink, "entity/Course.java",
+		`@ManyToMany(fetch=FetchType.LAZY, mappedBy="courses") private List<Student> students = new ArrayList<>();`,
+	)
+
+	// EXAM_RESULT has a payload, so it is an entity keyed by its two foreign keys
+	assertSource(t, sink, "entity/ExamResult.java",
+		`@EmbeddedId private ExamResultId id = new ExamResultId();`,
+		`@ManyToOne(fetch=FetchType.LAZY) @MapsId("examId") @JoinColumn(name="EXAM_ID") private Exam exam;`,
+		`@ManyToOne(fetch=FetchType.LAZY) @MapsId("studentId") @JoinColumn(name="STUDENT_ID") private Student student;`,
+	)
+	assertSource(t, sink, "entity/ExamResultId.java",
+		`@Embeddable public class ExamResultId implements Serializable`,
+		`private Integer examId;`,
+		`private Integer studentId;`,
+	)
+	// EXAM_RESULT is named after EXAM, the default cascade rule applies
+	assertSource(t, sink, "entity/Exam.java",
+		`@OneToMany(fetch=FetchType.LAZY, mappedBy="exam", cascade=CascadeType.ALL, orphanRemoval=true) private List<ExamResult> examResults`,
+	)
+
+	assertSource(t, sink, "entity/OrgUnit.java",
+		`@OneToMany(fetch=FetchType.LAZY, mappedBy="parent") private List<OrgUnit> children`,
+		`@ManyToOne(fetch=FetchType.LAZY) @JoinColumn(name="PARENT_ID") private OrgUnit parent;`,
+	)
+	assertSource(t, sink, "entity/Request.java",
+		`@ManyToOne(fetch=FetchType.LAZY) @JoinColumn(name="REQUESTER_ID") private Student requester;`,
+		`@ManyToOne(fetch=FetchType.LAZY) @JoinColumn(name="APPROVER_ID") private Student approver;`,
+	)
+}
+
+func TestGenerateCascadeByDeleteRule(t *testing.T) {
+	sink, _ := generateTestSchema(t, generator.Options{Parallel: 1, CascadeByDeleteRule: true}, "EXAM")
+	// the foreign key of EXAM_RESULT has no ON DELETE CASCADE
+	assertSource(t, sink, "entity/Exam.java",
+		`@OneToMany(fetch=FetchType.LAZY, mappedBy="exam") private List<ExamResult> examResults`,
+	)
+}
+
+func TestGenerateJoinTable(t *testing.T) {
+	// a join table named on its own is selected, but has no entity
+	sink, warnings := generateTestSchema(t, generator.Options{Parallel: 1}, "STUDENT_COURSE")
+	if len(sink) != 0 {
+		t.Errorf("artifacts generated for a join table: %v", len(sink))
+	}
+	want := "table MAIN.STUDENT_COURSE is a join table mapped by @ManyToMany, no entity is generated for it"
+	if len(warnings) != 1 || warnings[0] != want {
+		t.Errorf("warnings %q, want %q", warnings, want)
+	}
+}
+
+func TestGenerateConfig(t *testing.T) {
+	config := entityModel.Config{
+		ManyToMany: []entityModel.ManyToManyRule{{JoinTable: "STUDENT_COURSE", Owner: "COURSE", OwnerField: "attendee"}},
+		Tables:     entityModel.NameFilter{Exclude: []string{"REQUEST"}},
+	}
+	if _, err := generator.New(openTestSchema(t), generator.Options{Config: config}); err == nil {
+		t.Errorf("New accepts a configuration that is not compiled")
+	}
+	if err := config.Compile(); err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	sink, _ := generateTestSchema(t, generator.Options{Parallel: 2, Config: config}, "*")
+	if _, ok := sink["entity/Request.java"]; ok {
+		t.Errorf("excluded table REQUEST generated")
+	}
+	assertSource(t, sink, "entity/Course.java",
+		`@ManyToMany(fetch=FetchType.LAZY) @JoinTable(name="STUDENT_COURSE", schema="MAIN", `+
+			`joinColumns={@JoinColumn(name="COURSE_ID")}, inverseJoinColumns={@JoinColumn(name="STUDENT_ID")} ) `+
+			`private List<Student> attendees = new ArrayList<>();`,
+	)
+	assertSource(t, sink, "entity/Student.java",
+		`@ManyToMany(fetch=FetchType.LAZY, mappedBy="attendees") private List<Course> courses`,
+	)
+}
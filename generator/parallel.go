@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"strings"
+	"sync"
+)
+
+// parallel calls job with every index below n on at most Parallel goroutines.
+func (g *Generator) parallel(n int, job func(i int)) {
+	workers := g.options.Parallel
+	if workers < 1 {
+		workers = 1
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				job(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// errorList aggregates the errors of independent jobs.
+type errorList []error
+
+func (e errorList) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// err returns nil for an empty list.
+func (e errorList) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"tnd/work/generateJavaEntity/entityModel"
+	"tnd/work/generateJavaEntity/tableDefinition"
+)
+
+// SelectTables resolves the comma separated tableList of schemaName. An entry is
+// a table name, a glob such as ORDER_* or a regular expression between slashes (see
+// entityModel.NewNameMatcher); * selects the whole schema. Patterns leave out the
+// plain join tables, which are mapped by the @ManyToMany of the tables they join.
+// The result is sorted and free of duplicates.
+func (g *Generator) SelectTables(schemaName string, tableList string) ([]TableIdentity, error) {
+	var result []TableIdentity
+	selected := make(map[TableIdentity]bool)
+	add := func(table TableIdentity) {
+		if !selected[table] {
+			selected[table] = true
+			result = append(result, table)
+		}
+	}
+	var schemaTables []TableIdentity
+	for _, entry := range strings.Split(tableList, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !entityModel.IsNamePattern(entry) {
+			if table := (TableIdentity{Schema: schemaName, Name: entry}); g.options.Config.TableAllowed(table) {
+				add(table)
+			} else {
+				g.warnf("table %v is excluded by the configuration", entry)
+			}
+			continue
+		}
+		match, err := entityModel.NewNameMatcher(entry)
+		if err != nil {
+			return nil, fmt.Errorf("table pattern %v: %w", entry, err)
+		}
+		if schemaTables == nil {
+			var err error
+			if schemaTables, err = g.schema.ListTables(schemaName); err != nil {
+				return nil, fmt.Errorf("list tables %v: %w", schemaName, err)
+			}
+		}
+		var found bool
+		for _, table := range schemaTables {
+			if match(table.Name) && g.options.Config.TableAllowed(table) {
+				found = true
+				joinTable, err := g.isJoinTable(table)
+				if err != nil {
+					return nil, err
+				}
+				if !joinTable {
+					add(table)
+				}
+			}
+		}
+		if !found {
+			g.warnf("table pattern %v matches no table of schema %v", entry, schemaName)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no table selected by %q", tableList)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// isJoinTable reports whether table is a plain join table once filtered by the configuration.
+func (g *Generator) isJoinTable(table TableIdentity) (bool, error) {
+	tableDef, err := tableDefinition.GetTableDef(g.schema, table)
+	if err != nil {
+		return false, fmt.Errorf("get table def %v.%v: %w", table.Schema, table.Name, err)
+	}
+	return entityModel.IsTableManyToManyRelation(g.options.Config.FilterTableDef(tableDef)), nil
+}
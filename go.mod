@@ -2,4 +2,10 @@ module tnd/work/generateJavaEntity
 
 go 1.14
 
-require github.com/ibmdb/go_ibm_db v0.4.1
+require (
+	github.com/go-sql-driver/mysql v1.7.1
+	github.com/ibmdb/go_ibm_db v0.4.1
+	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.10
+	github.com/sijms/go-ora v1.3.2
+)
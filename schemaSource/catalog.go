@@ -0,0 +1,172 @@
+package schemaSource
+
+import (
+	"fmt"
+	"sync"
+	"tnd/work/generateJavaEntity/tableDefinition"
+)
+
+// SchemaLoader is implemented by providers able to read a whole schema at once.
+// LoadSchema returns the tables of schema and the foreign keys referencing them.
+type SchemaLoader interface {
+	LoadSchema(schema string) ([]TableDef, []ForeignKey, error)
+}
+
+// catalogSchema caches the metadata read from provider. The schemas of a SchemaLoader
+// are read in bulk the first time one of their tables is asked for, the tables of
+// other providers one by one. It is safe for concurrent use.
+type catalogSchema struct {
+	provider SchemaProvider
+	// loadLock serializes bulk loads, lock guards the maps
+	loadLock    sync.Mutex
+	lock        sync.Mutex
+	loaded      map[string]bool
+	schemas     map[string][]TableIdentity
+	tables      map[TableIdentity]TableDef
+	referencing map[TableIdentity][]ForeignKey
+}
+
+// NewCatalogSchema returns a cache of the metadata read from provider.
+func NewCatalogSchema(provider SchemaProvider) SchemaProvider {
+	return &catalogSchema{
+		provider:    provider,
+		loaded:      make(map[string]bool),
+		schemas:     make(map[string][]TableIdentity),
+		tables:      make(map[TableIdentity]TableDef),
+		referencing: make(map[TableIdentity][]ForeignKey),
+	}
+}
+
+func (c *catalogSchema) Dialect() string {
+	return c.provider.Dialect()
+}
+
+// load reads schema in bulk, reporting false when the provider can't.
+func (c *catalogSchema) load(schema string) (bool, error) {
+	loader, ok := c.provider.(SchemaLoader)
+	if !ok {
+		return false, nil
+	}
+	c.loadLock.Lock()
+	defer c.loadLock.Unlock()
+	c.lock.Lock()
+	loaded := c.loaded[schema]
+	c.lock.Unlock()
+	if loaded {
+		return true, nil
+	}
+	tables, referencing, err := loader.LoadSchema(schema)
+	if err != nil {
+		return true, fmt.Errorf("load schema %v: %w", schema, err)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.loaded[schema] = true
+	c.schemas[schema] = nil
+	for _, table := range tables {
+		c.schemas[schema] = append(c.schemas[schema], table.TableIdentity)
+		c.tables[table.TableIdentity] = table
+		c.referencing[table.TableIdentity] = nil
+	}
+	for _, fk := range referencing {
+		c.referencing[fk.To] = append(c.referencing[fk.To], fk)
+	}
+	return true, nil
+}
+
+func (c *catalogSchema) cachedTables(schema string) ([]TableIdentity, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tables, ok := c.schemas[schema]
+	return tables, ok
+}
+
+func (c *catalogSchema) cachedTable(table TableIdentity) (TableDef, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	def, ok := c.tables[table]
+	return def, ok
+}
+
+func (c *catalogSchema) cachedReferencing(table TableIdentity) ([]ForeignKey, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	fks, ok := c.referencing[table]
+	return fks, ok
+}
+
+func (c *catalogSchema) ListTables(schema string) ([]TableIdentity, error) {
+	if tables, ok := c.cachedTables(schema); ok {
+		return tables, nil
+	}
+	if ok, err := c.load(schema); ok || err != nil {
+		tables, _ := c.cachedTables(schema)
+		return tables, err
+	}
+	tables, err := c.provider.ListTables(schema)
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	c.schemas[schema] = tables
+	c.lock.Unlock()
+	return tables, nil
+}
+
+func (c *catalogSchema) table(table TableIdentity) (TableDef, error) {
+	if def, ok := c.cachedTable(table); ok {
+		return def, nil
+	}
+	if ok, err := c.load(table.Schema); err != nil {
+		return TableDef{}, err
+	} else if ok {
+		if def, ok := c.cachedTable(table); ok {
+			return def, nil
+		}
+	}
+	// tables the bulk load doesn't list, such as views, are read one by one
+	def, err := tableDefinition.GetTableDef(c.provider, table)
+	if err != nil {
+		return def, err
+	}
+	c.lock.Lock()
+	c.tables[table] = def
+	c.lock.Unlock()
+	return def, nil
+}
+
+func (c *catalogSchema) ListColumns(table TableIdentity) ([]ColumnDef, error) {
+	def, err := c.table(table)
+	return def.Columns, err
+}
+
+func (c *catalogSchema) ListPrimaryKeys(table TableIdentity) (map[string]bool, error) {
+	def, err := c.table(table)
+	return def.PrimaryKeys, err
+}
+
+func (c *catalogSchema) ListForeignKeysFrom(table TableIdentity) ([]ForeignKey, error) {
+	def, err := c.table(table)
+	return def.ForeignKeys, err
+}
+
+func (c *catalogSchema) ListForeignKeysTo(table TableIdentity) ([]ForeignKey, error) {
+	if fks, ok := c.cachedReferencing(table); ok {
+		return fks, nil
+	}
+	if ok, err := c.load(table.Schema); err != nil {
+		return nil, err
+	} else if ok {
+		if fks, ok := c.cachedReferencing(table); ok {
+			return fks, nil
+		}
+	}
+	fks, err := c.provider.ListForeignKeysTo(table)
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	c.referencing[table] = fks
+	c.lock.Unlock()
+	return fks, nil
+}
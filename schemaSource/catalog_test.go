@@ -0,0 +1,205 @@
+package schemaSource
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"tnd/work/generateJavaEntity/tableDefinition"
+)
+
+const catalogDDL = `
+CREATE TABLE DEPT (ID BIGINT NOT NULL PRIMARY KEY);
+CREATE TABLE EMP (ID BIGINT NOT NULL PRIMARY KEY, DEPT_ID BIGINT REFERENCES DEPT);
+CREATE TABLE PROJECT (ID BIGINT NOT NULL PRIMARY KEY, LEAD_ID BIGINT REFERENCES EMP, DEPT_ID BIGINT REFERENCES DEPT);
+`
+
+// countingSchema counts the calls of the catalog methods of a snapshot.
+type countingSchema struct {
+	SchemaProvider
+	lock  sync.Mutex
+	calls map[string]int
+}
+
+func newCountingSchema(t *testing.T) *countingSchema {
+	t.Helper()
+	snapshot, err := ParseDDL("ONLDB", strings.NewReader(catalogDDL))
+	if err != nil {
+		t.Fatalf("ParseDDL: %v", err)
+	}
+	return &countingSchema{SchemaProvider: NewSnapshotSchema(snapshot), calls: make(map[string]int)}
+}
+
+func (s *countingSchema) count(method string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.calls[method]++
+}
+
+func (s *countingSchema) ListTables(schema string) ([]TableIdentity, error) {
+	s.count("ListTables")
+	return s.SchemaProvider.ListTables(schema)
+}
+
+func (s *countingSchema) ListColumns(table TableIdentity) ([]ColumnDef, error) {
+	s.count("ListColumns")
+	return s.SchemaProvider.ListColumns(table)
+}
+
+func (s *countingSchema) ListPrimaryKeys(table TableIdentity) (map[string]bool, error) {
+	s.count("ListPrimaryKeys")
+	return s.SchemaProvider.ListPrimaryKeys(table)
+}
+
+func (s *countingSchema) ListForeignKeysFrom(table TableIdentity) ([]ForeignKey, error) {
+	s.count("ListForeignKeysFrom")
+	return s.SchemaProvider.ListForeignKeysFrom(table)
+}
+
+func (s *countingSchema) ListForeignKeysTo(table TableIdentity) ([]ForeignKey, error) {
+	s.count("ListForeignKeysTo")
+	return s.SchemaProvider.ListForeignKeysTo(table)
+}
+
+// bulkSchema loads its schema in bulk, except the tables in skip, like the views
+// of db2Schema, and fails with err when it is set.
+type bulkSchema struct {
+	*countingSchema
+	skip map[string]bool
+	err  error
+}
+
+func (s bulkSchema) LoadSchema(schema string) ([]TableDef, []ForeignKey, error) {
+	s.count("LoadSchema")
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	tables, err := s.SchemaProvider.ListTables(schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	var defs []TableDef
+	var referencing []ForeignKey
+	for _, table := range tables {
+		if s.skip[table.Name] {
+			continue
+		}
+		def, err := tableDefinition.GetTableDef(s.SchemaProvider, table)
+		if err != nil {
+			return nil, nil, err
+		}
+		defs = append(defs, def)
+		referencing = append(referencing, def.ForeignKeys...)
+	}
+	return defs, referencing, nil
+}
+
+// readCatalog reads every table of ONLDB from schema, twice.
+func readCatalog(t *testing.T, schema SchemaProvider) map[string]int {
+	t.Helper()
+	referencing := make(map[string]int)
+	for i := 0; i < 2; i++ {
+		tables, err := schema.ListTables("ONLDB")
+		if err != nil {
+			t.Fatalf("ListTables: %v", err)
+		}
+		if len(tables) != 3 {
+			t.Fatalf("got %v tables, want 3", len(tables))
+		}
+		for _, table := range tables {
+			if _, err := tableDefinition.GetTableDef(schema, table); err != nil {
+				t.Fatalf("GetTableDef %v: %v", table.Name, err)
+			}
+			fks, err := schema.ListForeignKeysTo(table)
+			if err != nil {
+				t.Fatalf("ListForeignKeysTo %v: %v", table.Name, err)
+			}
+			referencing[table.Name] = len(fks)
+		}
+	}
+	return referencing
+}
+
+var wantReferencing = map[string]int{"DEPT": 2, "EMP": 1, "PROJECT": 0}
+
+func TestCatalogSchemaPerTable(t *testing.T) {
+	provider := newCountingSchema(t)
+	referencing := readCatalog(t, NewCatalogSchema(provider))
+	if !reflect.DeepEqual(referencing, wantReferencing) {
+		t.Errorf("referencing foreign keys %v, want %v", referencing, wantReferencing)
+	}
+	want := map[string]int{
+		"ListTables":          1,
+		"ListColumns":         3,
+		"ListPrimaryKeys":     3,
+		"ListForeignKeysFrom": 3,
+		"ListForeignKeysTo":   3,
+	}
+	if !reflect.DeepEqual(provider.calls, want) {
+		t.Errorf("calls %v, want %v", provider.calls, want)
+	}
+}
+
+func TestCatalogSchemaBulk(t *testing.T) {
+	provider := newCountingSchema(t)
+	referencing := readCatalog(t, NewCatalogSchema(bulkSchema{countingSchema: provider}))
+	if !reflect.DeepEqual(referencing, wantReferencing) {
+		t.Errorf("referencing foreign keys %v, want %v", referencing, wantReferencing)
+	}
+	if want := map[string]int{"LoadSchema": 1}; !reflect.DeepEqual(provider.calls, want) {
+		t.Errorf("calls %v, want %v", provider.calls, want)
+	}
+}
+
+func TestCatalogSchemaBulkFallback(t *testing.T) {
+	// PROJECT is left out of the bulk load, and read on its own
+	provider := newCountingSchema(t)
+	catalog := NewCatalogSchema(bulkSchema{countingSchema: provider, skip: map[string]bool{"PROJECT": true}})
+	for i := 0; i < 2; i++ {
+		for _, name := range []string{"DEPT", "EMP", "PROJECT"} {
+			if _, err := tableDefinition.GetTableDef(catalog, TableIdentity{Schema: "ONLDB", Name: name}); err != nil {
+				t.Fatalf("GetTableDef %v: %v", name, err)
+			}
+		}
+	}
+	want := map[string]int{
+		"LoadSchema":          1,
+		"ListColumns":         1,
+		"ListPrimaryKeys":     1,
+		"ListForeignKeysFrom": 1,
+	}
+	if !reflect.DeepEqual(provider.calls, want) {
+		t.Errorf("calls %v, want %v", provider.calls, want)
+	}
+}
+
+func TestCatalogSchemaConcurrentLoad(t *testing.T) {
+	provider := newCountingSchema(t)
+	catalog := NewCatalogSchema(bulkSchema{countingSchema: provider})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := tableDefinition.GetTableDef(catalog, TableIdentity{Schema: "ONLDB", Name: "EMP"}); err != nil {
+				t.Errorf("GetTableDef: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if provider.calls["LoadSchema"] != 1 {
+		t.Errorf("schema loaded %v times, want once", provider.calls["LoadSchema"])
+	}
+}
+
+func TestCatalogSchemaLoadError(t *testing.T) {
+	loadErr := errors.New("connection reset")
+	catalog := NewCatalogSchema(bulkSchema{countingSchema: newCountingSchema(t), err: loadErr})
+	if _, err := catalog.ListTables("ONLDB"); !errors.Is(err, loadErr) {
+		t.Errorf("ListTables error %v, want %v", err, loadErr)
+	}
+	if _, err := catalog.ListColumns(TableIdentity{Schema: "ONLDB", Name: "EMP"}); !errors.Is(err, loadErr) {
+		t.Errorf("ListColumns error %v, want %v", err, loadErr)
+	}
+}
@@ -0,0 +1,744 @@
+package schemaSource
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type ddlTokenKind int
+
+const (
+	ddlWord ddlTokenKind = iota
+	ddlQuoted
+	ddlString
+	ddlNumber
+	ddlSymbol
+)
+
+type ddlToken struct {
+	kind ddlTokenKind
+	text string
+}
+
+// is reports whether the token is the given keyword or symbol. Quoted identifiers never match.
+func (t ddlToken) is(text string) bool {
+	return (t.kind == ddlWord || t.kind == ddlSymbol) && t.text == text
+}
+
+func tokenizeDDL(text string) ([]ddlToken, error) {
+	var tokens []ddlToken
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			j := i + 2
+			for j+1 < len(runes) && !(runes[j] == '*' && runes[j+1] == '/') {
+				j++
+			}
+			if j+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i = j + 2
+		case r == '"' || r == '\'':
+			var value []rune
+			j := i + 1
+			for {
+				if j >= len(runes) {
+					return nil, fmt.Errorf("unterminated %c", r)
+				}
+				if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						value = append(value, r)
+						j += 2
+						continue
+					}
+					break
+				}
+				value = append(value, runes[j])
+				j++
+			}
+			if r == '"' {
+				// db2look pads schema names with blanks
+				tokens = append(tokens, ddlToken{kind: ddlQuoted, text: strings.TrimRight(string(value), " ")})
+			} else {
+				tokens = append(tokens, ddlToken{kind: ddlString, text: string(value)})
+			}
+			i = j + 1
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlNumber, text: string(runes[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || strings.ContainsRune("_$#@", runes[j])) {
+				j++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlWord, text: strings.ToUpper(string(runes[i:j]))})
+			i = j
+		default:
+			tokens = append(tokens, ddlToken{kind: ddlSymbol, text: string(r)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+type ddlParser struct {
+	defaultSchema string
+	tables        []TableDef
+	index         map[TableIdentity]int
+	// foreign keys whose REFERENCES clause omits the column list, resolved once all tables are known
+	pendingFks []pendingForeignKey
+}
+
+type pendingForeignKey struct {
+	table int
+	fk    int
+}
+
+// ddlStatement is a cursor over the tokens of one statement.
+type ddlStatement struct {
+	tokens []ddlToken
+	pos    int
+}
+
+func (s *ddlStatement) done() bool {
+	return s.pos >= len(s.tokens)
+}
+
+func (s *ddlStatement) peek() ddlToken {
+	if s.done() {
+		return ddlToken{kind: ddlSymbol}
+	}
+	return s.tokens[s.pos]
+}
+
+func (s *ddlStatement) next() ddlToken {
+	t := s.peek()
+	s.pos++
+	return t
+}
+
+// accept consumes the given sequence of keywords if present.
+func (s *ddlStatement) accept(words ...string) bool {
+	for i, word := range words {
+		if s.pos+i >= len(s.tokens) || !s.tokens[s.pos+i].is(word) {
+			return false
+		}
+	}
+	s.pos += len(words)
+	return true
+}
+
+func (s *ddlStatement) expect(words ...string) error {
+	if !s.accept(words...) {
+		return fmt.Errorf("expected %v near %q", strings.Join(words, " "), s.peek().text)
+	}
+	return nil
+}
+
+func (s *ddlStatement) identifier() (string, error) {
+	t := s.next()
+	if t.kind != ddlWord && t.kind != ddlQuoted {
+		return "", fmt.Errorf("expected identifier near %q", t.text)
+	}
+	return t.text, nil
+}
+
+func (s *ddlStatement) tableName(defaultSchema string) (TableIdentity, error) {
+	name, err := s.identifier()
+	if err != nil {
+		return TableIdentity{}, err
+	}
+	if s.accept(".") {
+		table, err := s.identifier()
+		if err != nil {
+			return TableIdentity{}, err
+		}
+		return TableIdentity{Schema: name, Name: table}, nil
+	}
+	return TableIdentity{Schema: defaultSchema, Name: name}, nil
+}
+
+// parenthesized returns the tokens between the opening parenthesis at the cursor and its match.
+func (s *ddlStatement) parenthesized() ([]ddlToken, error) {
+	if err := s.expect("("); err != nil {
+		return nil, err
+	}
+	start := s.pos
+	for depth := 1; !s.done(); {
+		t := s.next()
+		if t.is("(") {
+			depth++
+		} else if t.is(")") {
+			depth--
+			if depth == 0 {
+				return s.tokens[start : s.pos-1], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unbalanced parenthesis")
+}
+
+func (s *ddlStatement) columnList() ([]string, error) {
+	tokens, err := s.parenthesized()
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, part := range splitDDLTokens(tokens) {
+		if len(part) == 0 {
+			return nil, fmt.Errorf("empty column name")
+		}
+		// ignore ASC/DESC and similar trailing options
+		result = append(result, part[0].text)
+	}
+	return result, nil
+}
+
+// skipUntil advances the cursor to the next top level token that is one of words.
+func (s *ddlStatement) skipUntil(words ...string) {
+	for depth := 0; !s.done(); s.pos++ {
+		t := s.peek()
+		if t.is("(") {
+			depth++
+		} else if t.is(")") {
+			depth--
+		} else if depth == 0 {
+			for _, word := range words {
+				if t.is(word) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// splitDDLTokens splits tokens on top level commas.
+func splitDDLTokens(tokens []ddlToken) [][]ddlToken {
+	var result [][]ddlToken
+	depth, start := 0, 0
+	for i, t := range tokens {
+		if t.is("(") {
+			depth++
+		} else if t.is(")") {
+			depth--
+		} else if t.is(",") && depth == 0 {
+			result = append(result, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(result, tokens[start:])
+}
+
+// ParseDDL reads DB2 CREATE TABLE and ALTER TABLE statements, as written by db2look,
+// into a Snapshot. Unqualified table names belong to defaultSchema until a SET SCHEMA statement.
+func ParseDDL(defaultSchema string, readers ...io.Reader) (Snapshot, error) {
+	parser := ddlParser{
+		defaultSchema: defaultSchema,
+		index:         make(map[TableIdentity]int),
+	}
+	for _, reader := range readers {
+		text, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("read ddl: %w", err)
+		}
+		tokens, err := tokenizeDDL(string(text))
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("tokenize ddl: %w", err)
+		}
+		start := 0
+		for i := 0; i <= len(tokens); i++ {
+			if i < len(tokens) && !tokens[i].is(";") {
+				continue
+			}
+			if i > start {
+				statement := &ddlStatement{tokens: tokens[start:i]}
+				if err := parser.parseStatement(statement); err != nil {
+					return Snapshot{}, fmt.Errorf("statement %v: %w", statementText(tokens[start:i]), err)
+				}
+			}
+			start = i + 1
+		}
+	}
+	if err := parser.resolvePendingFks(); err != nil {
+		return Snapshot{}, err
+	}
+	return Snapshot{
+		Version: snapshotVersion,
+		Dialect: "db2",
+		Tables:  parser.tables,
+	}, nil
+}
+
+func statementText(tokens []ddlToken) string {
+	var words []string
+	for i := 0; i < len(tokens) && i < 6; i++ {
+		words = append(words, tokens[i].text)
+	}
+	return strings.Join(words, " ")
+}
+
+func (p *ddlParser) parseStatement(s *ddlStatement) error {
+	switch {
+	case s.accept("SET", "CURRENT", "SCHEMA"), s.accept("SET", "SCHEMA"):
+		s.accept("=")
+		schema, err := s.identifier()
+		if err != nil {
+			return err
+		}
+		p.defaultSchema = schema
+	case s.accept("CREATE", "TABLE"):
+		return p.parseCreateTable(s)
+	case s.accept("ALTER", "TABLE"):
+		return p.parseAlterTable(s)
+	case s.accept("COMMENT", "ON", "COLUMN"):
+		return p.parseColumnComment(s)
+	}
+	return nil
+}
+
+// parseColumnComment handles COMMENT ON COLUMN [schema.]table.column IS 'remarks'.
+func (p *ddlParser) parseColumnComment(s *ddlStatement) error {
+	var names []string
+	for {
+		name, err := s.identifier()
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+		if !s.accept(".") {
+			break
+		}
+	}
+	if len(names) < 2 {
+		return fmt.Errorf("expected table.column")
+	}
+	identity := TableIdentity{Schema: p.defaultSchema, Name: names[len(names)-2]}
+	if len(names) > 2 {
+		identity.Schema = names[len(names)-3]
+	}
+	if err := s.expect("IS"); err != nil {
+		return err
+	}
+	remarks := s.next()
+	if remarks.kind != ddlString {
+		return fmt.Errorf("expected comment string near %q", remarks.text)
+	}
+	i, ok := p.index[identity]
+	if !ok {
+		return fmt.Errorf("comment on unknown table %v.%v", identity.Schema, identity.Name)
+	}
+	for j := range p.tables[i].Columns {
+		if p.tables[i].Columns[j].Name == names[len(names)-1] {
+			p.tables[i].Columns[j].Remarks = remarks.text
+			return nil
+		}
+	}
+	return fmt.Errorf("comment on unknown column %v of %v.%v", names[len(names)-1], identity.Schema, identity.Name)
+}
+
+func (p *ddlParser) parseCreateTable(s *ddlStatement) error {
+	identity, err := s.tableName(p.defaultSchema)
+	if err != nil {
+		return err
+	}
+	if !s.peek().is("(") {
+		// CREATE TABLE ... LIKE / AS carry no column definitions of their own
+		return nil
+	}
+	if _, ok := p.index[identity]; ok {
+		return fmt.Errorf("table %v.%v defined twice", identity.Schema, identity.Name)
+	}
+	p.index[identity] = len(p.tables)
+	p.tables = append(p.tables, TableDef{
+		TableIdentity: identity,
+		PrimaryKeys:   make(map[string]bool),
+	})
+	elements, err := s.parenthesized()
+	if err != nil {
+		return err
+	}
+	for _, element := range splitDDLTokens(elements) {
+		if err := p.parseTableElement(identity, &ddlStatement{tokens: element}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *ddlParser) parseAlterTable(s *ddlStatement) error {
+	identity, err := s.tableName(p.defaultSchema)
+	if err != nil {
+		return err
+	}
+	for !s.done() {
+		if !s.accept("ADD") {
+			s.next()
+			s.skipUntil("ADD")
+			continue
+		}
+		if _, ok := p.index[identity]; !ok {
+			return fmt.Errorf("table %v.%v altered before it is created", identity.Schema, identity.Name)
+		}
+		s.accept("COLUMN")
+		start := s.pos
+		s.skipUntil("ADD")
+		if err := p.parseTableElement(identity, &ddlStatement{tokens: s.tokens[start:s.pos]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTableElement handles a column definition or a table constraint.
+func (p *ddlParser) parseTableElement(identity TableIdentity, s *ddlStatement) error {
+	table := &p.tables[p.index[identity]]
+	var constname string
+	if s.accept("CONSTRAINT") {
+		name, err := s.identifier()
+		if err != nil {
+			return err
+		}
+		constname = name
+	}
+	switch {
+	case s.accept("PRIMARY", "KEY"):
+		columns, err := s.columnList()
+		if err != nil {
+			return err
+		}
+		for _, column := range columns {
+			table.PrimaryKeys[column] = true
+		}
+	case s.accept("FOREIGN", "KEY"):
+		columns, err := s.columnList()
+		if err != nil {
+			return err
+		}
+		return p.parseReferences(identity, constname, columns, s)
+	case s.accept("UNIQUE"), s.accept("CHECK"):
+	case constname != "":
+		return fmt.Errorf("unsupported constraint %v", constname)
+	default:
+		return p.parseColumn(identity, s)
+	}
+	return nil
+}
+
+func (p *ddlParser) parseReferences(identity TableIdentity, constname string, columns []string, s *ddlStatement) error {
+	if err := s.expect("REFERENCES"); err != nil {
+		return err
+	}
+	to, err := s.tableName(p.defaultSchema)
+	if err != nil {
+		return err
+	}
+	fk := ForeignKey{
+		Constname: constname,
+		From:      identity,
+		To:        to,
+		FkColumns: columns,
+	}
+	if s.peek().is("(") {
+		if fk.PkColumns, err = s.columnList(); err != nil {
+			return err
+		}
+	}
+	if fk.DeleteRule, fk.UpdateRule, err = parseReferentialRules(s); err != nil {
+		return err
+	}
+	tableIndex := p.index[identity]
+	table := &p.tables[tableIndex]
+	if fk.Constname == "" {
+		fk.Constname = fmt.Sprintf("FK_%v_%v", identity.Name, len(table.ForeignKeys)+1)
+	}
+	if len(fk.PkColumns) == 0 {
+		p.pendingFks = append(p.pendingFks, pendingForeignKey{table: tableIndex, fk: len(table.ForeignKeys)})
+	}
+	table.ForeignKeys = append(table.ForeignKeys, fk)
+	return nil
+}
+
+// parseReferentialRules reads the ON DELETE and ON UPDATE clauses of a foreign key.
+func parseReferentialRules(s *ddlStatement) (string, string, error) {
+	deleteRule, updateRule := "NO ACTION", "NO ACTION"
+	for s.accept("ON") {
+		var rule *string
+		switch {
+		case s.accept("DELETE"):
+			rule = &deleteRule
+		case s.accept("UPDATE"):
+			rule = &updateRule
+		default:
+			return "", "", fmt.Errorf("expected DELETE or UPDATE near %q", s.peek().text)
+		}
+		switch {
+		case s.accept("CASCADE"):
+			*rule = "CASCADE"
+		case s.accept("RESTRICT"):
+			*rule = "RESTRICT"
+		case s.accept("NO", "ACTION"):
+			*rule = "NO ACTION"
+		case s.accept("SET", "NULL"):
+			*rule = "SET NULL"
+		case s.accept("SET", "DEFAULT"):
+			*rule = "SET DEFAULT"
+		default:
+			return "", "", fmt.Errorf("unsupported referential action near %q", s.peek().text)
+		}
+	}
+	return deleteRule, updateRule, nil
+}
+
+func (p *ddlParser) resolvePendingFks() error {
+	for _, pending := range p.pendingFks {
+		fk := &p.tables[pending.table].ForeignKeys[pending.fk]
+		i, ok := p.index[fk.To]
+		if !ok {
+			return fmt.Errorf("foreign key %v references unknown table %v.%v", fk.Constname, fk.To.Schema, fk.To.Name)
+		}
+		var pkColumns []string
+		for _, col := range p.tables[i].Columns {
+			if p.tables[i].PrimaryKeys[col.Name] {
+				pkColumns = append(pkColumns, col.Name)
+			}
+		}
+		fk.PkColumns = pkColumns
+	}
+	return nil
+}
+
+func (p *ddlParser) parseColumn(identity TableIdentity, s *ddlStatement) error {
+	table := &p.tables[p.index[identity]]
+	name, err := s.identifier()
+	if err != nil {
+		return err
+	}
+	col := ColumnDef{
+		Position: len(table.Columns),
+		Name:     name,
+		Nullable: true,
+	}
+	if err := parseDDLType(s, &col); err != nil {
+		return fmt.Errorf("column %v: %w", name, err)
+	}
+	table.Columns = append(table.Columns, col)
+	def := &table.Columns[len(table.Columns)-1]
+	for !s.done() {
+		switch {
+		case s.accept("NOT", "NULL"):
+			def.Nullable = false
+		case s.accept("WITH", "DEFAULT"), s.accept("DEFAULT"):
+			def.Default = ddlDefaultValue(s)
+		case s.accept("GENERATED"):
+			if !s.accept("ALWAYS") {
+				s.accept("BY", "DEFAULT")
+			}
+			if s.accept("AS", "IDENTITY") {
+				def.Identity = true
+			} else {
+				// AS (expression) or AS ROW CHANGE TIMESTAMP
+				def.Generated = true
+			}
+		case s.accept("PRIMARY", "KEY"):
+			table.PrimaryKeys[name] = true
+			def.Nullable = false
+		case s.peek().is("REFERENCES"):
+			if err := p.parseReferences(identity, "", []string{name}, s); err != nil {
+				return err
+			}
+		case s.peek().is("("):
+			if _, err := s.parenthesized(); err != nil {
+				return err
+			}
+		default:
+			s.next()
+		}
+	}
+	return nil
+}
+
+// ddlDefaultValue reads the value of a DEFAULT clause, which may be omitted.
+func ddlDefaultValue(s *ddlStatement) string {
+	t := s.peek()
+	switch {
+	case t.kind == ddlString:
+		s.next()
+		return "'" + strings.Replace(t.text, "'", "''", -1) + "'"
+	case t.kind == ddlNumber:
+		s.next()
+		return t.text
+	case t.is("-") || t.is("+"):
+		s.next()
+		return t.text + s.next().text
+	case t.is("CURRENT"):
+		s.next()
+		return "CURRENT " + s.next().text
+	case t.is("NULL"), t.is("USER"), t.is("CURRENT_DATE"), t.is("CURRENT_TIME"), t.is("CURRENT_TIMESTAMP"):
+		s.next()
+		return t.text
+	}
+	return ""
+}
+
+// ddlTypeNames maps DDL type spellings to the type names found in syscat.columns.
+var ddlTypeNames = []struct {
+	words    []string
+	typeName string
+}{
+	{[]string{"CHARACTER", "LARGE", "OBJECT"}, "CLOB"},
+	{[]string{"CHAR", "LARGE", "OBJECT"}, "CLOB"},
+	{[]string{"BINARY", "LARGE", "OBJECT"}, "BLOB"},
+	{[]string{"CHARACTER", "VARYING"}, "VARCHAR"},
+	{[]string{"CHAR", "VARYING"}, "VARCHAR"},
+	{[]string{"DOUBLE", "PRECISION"}, "DOUBLE"},
+	{[]string{"LONG", "VARCHAR"}, "LONG VARCHAR"},
+	{[]string{"LONG", "VARGRAPHIC"}, "LONG VARGRAPHIC"},
+	{[]string{"CHAR"}, "CHARACTER"},
+	{[]string{"INT"}, "INTEGER"},
+	{[]string{"DEC"}, "DECIMAL"},
+	{[]string{"NUMERIC"}, "DECIMAL"},
+	{[]string{"NUM"}, "DECIMAL"},
+	{[]string{"FLOAT"}, "DOUBLE"},
+	{[]string{"NCHAR"}, "GRAPHIC"},
+	{[]string{"NVARCHAR"}, "VARGRAPHIC"},
+	{[]string{"NCLOB"}, "DBCLOB"},
+}
+
+// ddlTypeLengths holds the syscat.columns length of types declared without one.
+var ddlTypeLengths = map[string]int{
+	"SMALLINT":  2,
+	"INTEGER":   4,
+	"BIGINT":    8,
+	"REAL":      4,
+	"DOUBLE":    8,
+	"DECFLOAT":  16,
+	"DECIMAL":   5,
+	"DATE":      4,
+	"TIME":      3,
+	"TIMESTAMP": 10,
+	"CHARACTER": 1,
+	"GRAPHIC":   1,
+	"BINARY":    1,
+	"BOOLEAN":   1,
+}
+
+func parseDDLType(s *ddlStatement, col *ColumnDef) error {
+	if s.peek().kind == ddlQuoted || (s.pos+1 < len(s.tokens) && s.tokens[s.pos+1].is(".")) {
+		// user defined type: SCHEMA.TYPE
+		typeName, err := s.identifier()
+		if err != nil {
+			return err
+		}
+		if s.accept(".") {
+			if typeName, err = s.identifier(); err != nil {
+				return err
+			}
+		}
+		col.Type = typeName
+		return nil
+	}
+	col.Type = ""
+	for _, name := range ddlTypeNames {
+		if s.accept(name.words...) {
+			col.Type = name.typeName
+			break
+		}
+	}
+	if col.Type == "" {
+		typeName, err := s.identifier()
+		if err != nil {
+			return err
+		}
+		col.Type = typeName
+	}
+	col.Length = ddlTypeLengths[col.Type]
+	if col.Type == "TIMESTAMP" {
+		col.Scale = 6
+	}
+	if !s.peek().is("(") {
+		return nil
+	}
+	args, err := s.parenthesized()
+	if err != nil {
+		return err
+	}
+	parts := splitDDLTokens(args)
+	size, err := ddlSize(parts[0])
+	if err != nil {
+		return err
+	}
+	switch col.Type {
+	case "TIMESTAMP":
+		col.Scale = size
+	case "DECFLOAT":
+		if size == 16 {
+			col.Length = 8
+		}
+	case "DOUBLE":
+		if size <= 24 {
+			col.Type = "REAL"
+			col.Length = 4
+		}
+	default:
+		col.Length = size
+	}
+	if len(parts) > 1 {
+		if col.Scale, err = ddlSize(parts[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ddlSize reads a length such as 100, 100 OCTETS or 1M.
+func ddlSize(tokens []ddlToken) (int, error) {
+	if len(tokens) == 0 || tokens[0].kind != ddlNumber {
+		return 0, fmt.Errorf("expected size")
+	}
+	size, err := strconv.Atoi(tokens[0].text)
+	if err != nil {
+		return 0, fmt.Errorf("size %v: %w", tokens[0].text, err)
+	}
+	if len(tokens) > 1 {
+		switch tokens[1].text {
+		case "K":
+			size *= 1024
+		case "M":
+			size *= 1024 * 1024
+		case "G":
+			size *= 1024 * 1024 * 1024
+		}
+	}
+	return size, nil
+}
+
+// ReadDDLFiles parses the DDL scripts of fileNames with ParseDDL.
+func ReadDDLFiles(defaultSchema string, fileNames []string) (Snapshot, error) {
+	var readers []io.Reader
+	for _, fileName := range fileNames {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("os open %v: %w", fileName, err)
+		}
+		defer file.Close()
+		readers = append(readers, file)
+	}
+	return ParseDDL(defaultSchema, readers...)
+}
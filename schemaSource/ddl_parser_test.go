@@ -0,0 +1,302 @@
+package schemaSource
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// db2lookDDL is trimmed db2look output: schema names padded with blanks, the
+// primary and foreign keys of a table added by ALTER TABLE, and a comment.
+const db2lookDDL = `-- This CLP file was created using DB2LOOK Version "11.5"
+CONNECT TO IPDB;
+
+------------------------------------------------
+-- DDL Statements for Table "ONLDB   "."DEPT"
+------------------------------------------------
+CREATE TABLE "ONLDB   "."DEPT"  (
+		  "ID" BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY (
+		    START WITH +1
+		    INCREMENT BY +1
+		    CACHE 20
+		    NO ORDER ) ,
+		  "NAME" VARGRAPHIC(100 CODEUNITS16) NOT NULL ,
+		  "ACTIVE" CHAR(1 OCTETS) NOT NULL WITH DEFAULT 'Y' )
+		 IN "USERSPACE1"
+		 ORGANIZE BY ROW;
+
+ALTER TABLE "ONLDB   "."DEPT"
+	ADD CONSTRAINT "PK_DEPT" PRIMARY KEY
+		("ID");
+
+------------------------------------------------
+-- DDL Statements for Table "ONLDB   "."COMPENSATION"
+------------------------------------------------
+CREATE TABLE "ONLDB   "."COMPENSATION"  (
+		  "ID" BIGINT NOT NULL ,
+		  "DEPT_ID" BIGINT ,
+		  "AMOUNT" DECIMAL(15,2) NOT NULL WITH DEFAULT 0 ,
+		  "NOTE" CLOB(1048576 OCTETS) LOGGED NOT COMPACT ,
+		  "CREATED_DATE" TIMESTAMP NOT NULL WITH DEFAULT CURRENT TIMESTAMP )
+		 IN "USERSPACE1"
+		 ORGANIZE BY ROW;
+
+ALTER TABLE "ONLDB   "."COMPENSATION"
+	ADD CONSTRAINT "PK_COMPENSATION" PRIMARY KEY
+		("ID");
+
+ALTER TABLE "ONLDB   "."COMPENSATION"
+	ADD CONSTRAINT "FK_COMP_DEPT" FOREIGN KEY
+		("DEPT_ID")
+	REFERENCES "ONLDB   "."DEPT"
+		("ID")
+	ON DELETE RESTRICT
+	ON UPDATE NO ACTION
+	ENFORCED
+	ENABLE QUERY OPTIMIZATION;
+
+COMMENT ON COLUMN "ONLDB   "."DEPT"."NAME" IS 'Department name shown on reports';
+
+COMMIT WORK;
+
+CONNECT RESET;
+
+TERMINATE;
+`
+
+func parseTestDDL(t *testing.T, ddl string) Snapshot {
+	t.Helper()
+	snapshot, err := ParseDDL("ONLDB", strings.NewReader(ddl))
+	if err != nil {
+		t.Fatalf("ParseDDL: %v", err)
+	}
+	return snapshot
+}
+
+func testTable(t *testing.T, snapshot Snapshot, name string) TableDef {
+	t.Helper()
+	for _, table := range snapshot.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	t.Fatalf("table %v not parsed", name)
+	return TableDef{}
+}
+
+func testColumn(t *testing.T, table TableDef, name string) ColumnDef {
+	t.Helper()
+	for _, col := range table.Columns {
+		if col.Name == name {
+			return col
+		}
+	}
+	t.Fatalf("column %v of %v not parsed", name, table.Name)
+	return ColumnDef{}
+}
+
+func TestParseDDLDb2look(t *testing.T) {
+	snapshot := parseTestDDL(t, db2lookDDL)
+	if snapshot.Dialect != "db2" || snapshot.Version != snapshotVersion {
+		t.Errorf("dialect %q version %v", snapshot.Dialect, snapshot.Version)
+	}
+	if len(snapshot.Tables) != 2 {
+		t.Fatalf("got %v tables, want 2", len(snapshot.Tables))
+	}
+	for _, table := range snapshot.Tables {
+		if table.Schema != "ONLDB" {
+			t.Errorf("table %v: schema %q, want padding blanks trimmed", table.Name, table.Schema)
+		}
+	}
+
+	dept := testTable(t, snapshot, "DEPT")
+	if !reflect.DeepEqual(dept.PrimaryKeys, map[string]bool{"ID": true}) {
+		t.Errorf("DEPT primary keys %v", dept.PrimaryKeys)
+	}
+	if id := testColumn(t, dept, "ID"); !id.Identity || id.Nullable || id.Type != "BIGINT" || id.Length != 8 {
+		t.Errorf("DEPT.ID %+v", id)
+	}
+	name := testColumn(t, dept, "NAME")
+	if name.Type != "VARGRAPHIC" || name.Length != 100 || name.Nullable {
+		t.Errorf("DEPT.NAME %+v", name)
+	}
+	if name.Remarks != "Department name shown on reports" {
+		t.Errorf("DEPT.NAME remarks %q", name.Remarks)
+	}
+	if active := testColumn(t, dept, "ACTIVE"); active.Type != "CHARACTER" || active.Length != 1 || active.Default != "'Y'" {
+		t.Errorf("DEPT.ACTIVE %+v", active)
+	}
+
+	compensation := testTable(t, snapshot, "COMPENSATION")
+	for i, col := range compensation.Columns {
+		if col.Position != i {
+			t.Errorf("COMPENSATION.%v position %v, want %v", col.Name, col.Position, i)
+		}
+	}
+	if amount := testColumn(t, compensation, "AMOUNT"); amount.Type != "DECIMAL" || amount.Length != 15 || amount.Scale != 2 || amount.Default != "0" {
+		t.Errorf("COMPENSATION.AMOUNT %+v", amount)
+	}
+	if note := testColumn(t, compensation, "NOTE"); note.Type != "CLOB" || note.Length != 1048576 || !note.Nullable {
+		t.Errorf("COMPENSATION.NOTE %+v", note)
+	}
+	if created := testColumn(t, compensation, "CREATED_DATE"); created.Default != "CURRENT TIMESTAMP" || created.Scale != 6 {
+		t.Errorf("COMPENSATION.CREATED_DATE %+v", created)
+	}
+	want := []ForeignKey{{
+		Constname:  "FK_COMP_DEPT",
+		From:       TableIdentity{Schema: "ONLDB", Name: "COMPENSATION"},
+		To:         TableIdentity{Schema: "ONLDB", Name: "DEPT"},
+		FkColumns:  []string{"DEPT_ID"},
+		PkColumns:  []string{"ID"},
+		DeleteRule: "RESTRICT",
+		UpdateRule: "NO ACTION",
+	}}
+	if !reflect.DeepEqual(compensation.ForeignKeys, want) {
+		t.Errorf("COMPENSATION foreign keys\n got %+v\nwant %+v", compensation.ForeignKeys, want)
+	}
+}
+
+func TestParseDDLReferencesWithoutColumns(t *testing.T) {
+	// ORDER_LINE references ORDERS before it is created, the referenced columns
+	// are its primary key once the whole script is read
+	snapshot := parseTestDDL(t, `
+SET SCHEMA SALES;
+CREATE TABLE ORDER_LINE (
+	ORDER_ID BIGINT NOT NULL REFERENCES ORDERS ON DELETE CASCADE ON UPDATE RESTRICT,
+	LINE_NO INTEGER NOT NULL,
+	CONSTRAINT FK_LINE_ITEM FOREIGN KEY (ITEM_CODE, ITEM_VARIANT) REFERENCES "CATALOG"."ITEM" ON DELETE SET NULL,
+	ITEM_CODE VARCHAR(10),
+	ITEM_VARIANT SMALLINT,
+	PRIMARY KEY (ORDER_ID, LINE_NO));
+CREATE TABLE ORDERS (ID BIGINT NOT NULL PRIMARY KEY);
+CREATE TABLE "CATALOG"."ITEM" (CODE VARCHAR(10) NOT NULL, VARIANT SMALLINT NOT NULL, PRIMARY KEY (CODE, VARIANT));
+`)
+	line := testTable(t, snapshot, "ORDER_LINE")
+	if line.Schema != "SALES" {
+		t.Errorf("ORDER_LINE schema %q, want SET SCHEMA to apply", line.Schema)
+	}
+	want := []ForeignKey{{
+		Constname:  "FK_ORDER_LINE_1",
+		From:       TableIdentity{Schema: "SALES", Name: "ORDER_LINE"},
+		To:         TableIdentity{Schema: "SALES", Name: "ORDERS"},
+		FkColumns:  []string{"ORDER_ID"},
+		PkColumns:  []string{"ID"},
+		DeleteRule: "CASCADE",
+		UpdateRule: "RESTRICT",
+	}, {
+		Constname:  "FK_LINE_ITEM",
+		From:       TableIdentity{Schema: "SALES", Name: "ORDER_LINE"},
+		To:         TableIdentity{Schema: "CATALOG", Name: "ITEM"},
+		FkColumns:  []string{"ITEM_CODE", "ITEM_VARIANT"},
+		PkColumns:  []string{"CODE", "VARIANT"},
+		DeleteRule: "SET NULL",
+		UpdateRule: "NO ACTION",
+	}}
+	if !reflect.DeepEqual(line.ForeignKeys, want) {
+		t.Errorf("ORDER_LINE foreign keys\n got %+v\nwant %+v", line.ForeignKeys, want)
+	}
+	if !reflect.DeepEqual(line.PrimaryKeys, map[string]bool{"ORDER_ID": true, "LINE_NO": true}) {
+		t.Errorf("ORDER_LINE primary keys %v", line.PrimaryKeys)
+	}
+}
+
+func TestParseDDLColumnTypes(t *testing.T) {
+	tests := []struct {
+		definition string
+		typeName   string
+		length     int
+		scale      int
+	}{
+		{"VARCHAR(100 OCTETS)", "VARCHAR", 100, 0},
+		{"CHARACTER VARYING(20)", "VARCHAR", 20, 0},
+		{"CHAR(3 OCTETS) FOR BIT DATA", "CHARACTER", 3, 0},
+		{"CHAR", "CHARACTER", 1, 0},
+		{"INT", "INTEGER", 4, 0},
+		{"DEC(9,2)", "DECIMAL", 9, 2},
+		{"NUMERIC", "DECIMAL", 5, 0},
+		{"FLOAT(24)", "REAL", 4, 0},
+		{"FLOAT(53)", "DOUBLE", 8, 0},
+		{"FLOAT", "DOUBLE", 8, 0},
+		{"DOUBLE PRECISION", "DOUBLE", 8, 0},
+		{"DECFLOAT(16)", "DECFLOAT", 8, 0},
+		{"TIMESTAMP", "TIMESTAMP", 10, 6},
+		{"TIMESTAMP(3)", "TIMESTAMP", 10, 3},
+		{"TIMESTAMP(0) WITH DEFAULT", "TIMESTAMP", 10, 0},
+		{"CLOB(1M) LOGGED NOT COMPACT", "CLOB", 1024 * 1024, 0},
+		{"BLOB(2 K)", "BLOB", 2048, 0},
+		{"LONG VARCHAR", "LONG VARCHAR", 0, 0},
+		{`"ONLDB"."MONEY"`, "MONEY", 0, 0},
+	}
+	for _, test := range tests {
+		snapshot := parseTestDDL(t, "CREATE TABLE T (C "+test.definition+");")
+		col := testColumn(t, testTable(t, snapshot, "T"), "C")
+		if col.Type != test.typeName || col.Length != test.length || col.Scale != test.scale {
+			t.Errorf("%v: got %v(%v,%v), want %v(%v,%v)", test.definition, col.Type, col.Length, col.Scale, test.typeName, test.length, test.scale)
+		}
+	}
+}
+
+func TestParseDDLGeneratedColumns(t *testing.T) {
+	snapshot := parseTestDDL(t, `CREATE TABLE T (
+	ID INTEGER NOT NULL GENERATED ALWAYS AS IDENTITY (START WITH 1, INCREMENT BY 1, NO CACHE),
+	SEQ BIGINT GENERATED BY DEFAULT AS IDENTITY,
+	TOTAL DECIMAL(9,2) GENERATED ALWAYS AS (PRICE * QTY),
+	CHANGED TIMESTAMP NOT NULL GENERATED ALWAYS FOR EACH ROW ON UPDATE AS ROW CHANGE TIMESTAMP,
+	PRICE DECIMAL(7,2) WITH DEFAULT -1.5,
+	QTY INTEGER DEFAULT NULL);`)
+	table := testTable(t, snapshot, "T")
+	tests := []struct {
+		name      string
+		identity  bool
+		generated bool
+		def       string
+	}{
+		{"ID", true, false, ""},
+		{"SEQ", true, false, ""},
+		{"TOTAL", false, true, ""},
+		{"CHANGED", false, true, ""},
+		{"PRICE", false, false, "-1.5"},
+		{"QTY", false, false, "NULL"},
+	}
+	if len(table.Columns) != len(tests) {
+		t.Fatalf("got %v columns, want %v", len(table.Columns), len(tests))
+	}
+	for _, test := range tests {
+		col := testColumn(t, table, test.name)
+		if col.Identity != test.identity || col.Generated != test.generated || col.Default != test.def {
+			t.Errorf("%v: identity %v generated %v default %q, want %v %v %q", test.name, col.Identity, col.Generated, col.Default, test.identity, test.generated, test.def)
+		}
+	}
+}
+
+func TestParseDDLErrors(t *testing.T) {
+	tests := []struct {
+		ddl string
+		err string
+	}{
+		{"CREATE TABLE T (ID INTEGER); /* not closed", "unterminated comment"},
+		{`CREATE TABLE "T (ID INTEGER);`, "unterminated \""},
+		{"COMMENT ON COLUMN T.ID IS 'not closed;", "unterminated '"},
+		{"CREATE TABLE T (ID INTEGER); CREATE TABLE T (ID INTEGER);", "table ONLDB.T defined twice"},
+		{"ALTER TABLE T ADD PRIMARY KEY (ID);", "table ONLDB.T altered before it is created"},
+		{"CREATE TABLE T (ID INTEGER REFERENCES MISSING);", "foreign key FK_T_1 references unknown table ONLDB.MISSING"},
+		{"CREATE TABLE T (ID INTEGER REFERENCES U (ID) ON DELETE SOMETIMES);", "unsupported referential action near \"SOMETIMES\""},
+		{"CREATE TABLE T (ID INTEGER REFERENCES U (ID) ON INSERT CASCADE);", "expected DELETE or UPDATE near \"INSERT\""},
+		{"CREATE TABLE T (ID INTEGER, CONSTRAINT C FOREIGN KEY (ID) U);", "expected REFERENCES near \"U\""},
+		{"CREATE TABLE T (ID INTEGER, CONSTRAINT C EXCLUDE (ID));", "unsupported constraint C"},
+		{"CREATE TABLE T (ID DECIMAL(X));", "column ID: expected size"},
+		{"CREATE TABLE T (ID INTEGER, PRIMARY KEY (ID);", "unbalanced parenthesis"},
+		{"CREATE TABLE T (ID INTEGER); COMMENT ON COLUMN U.ID IS 'x';", "comment on unknown table ONLDB.U"},
+		{"CREATE TABLE T (ID INTEGER); COMMENT ON COLUMN T.NAME IS 'x';", "comment on unknown column NAME of ONLDB.T"},
+		{"CREATE TABLE T (ID INTEGER); COMMENT ON COLUMN ID IS 'x';", "expected table.column"},
+		{"CREATE TABLE T (ID INTEGER); COMMENT ON COLUMN T.ID IS NULL;", "expected comment string near \"NULL\""},
+	}
+	for _, test := range tests {
+		_, err := ParseDDL("ONLDB", strings.NewReader(test.ddl))
+		if err == nil {
+			t.Errorf("%v: no error, want %q", test.ddl, test.err)
+		} else if !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%v: error %q, want %q", test.ddl, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package schemaSource provides the table definitions of database catalogs, schema
+// snapshots and DB2 DDL scripts as tableDefinition.SchemaProvider values. It doesn't
+// register the database/sql drivers: a program connecting to a database imports the
+// driver of its product, such as github.com/ibmdb/go_ibm_db for db2.
+package schemaSource
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// dialect describes how to connect to one database product and read its catalog.
+// The Java types of its columns are mapped by entityModel.JavaTypes.
+type dialect struct {
+	sqlDriver       string
+	connectTemplate string
+	newSchema       func(db *sql.DB) SchemaProvider
+}
+
+var dialects = map[string]dialect{
+	"db2": {
+		sqlDriver:       "go_ibm_db",
+		connectTemplate: "HOSTNAME={{.Host}};DATABASE={{.Database}};PORT={{.Port}};UID={{.UID}};PWD={{.PWD}};AUTHENTICATION=SERVER",
+		newSchema: func(db *sql.DB) SchemaProvider {
+			return db2Schema{db: db}
+		},
+	},
+	"postgres": {
+		sqlDriver:       "postgres",
+		connectTemplate: "host={{.Host}} port={{.Port}} dbname={{.Database}} user={{.UID}} password={{.PWD}}",
+		newSchema: func(db *sql.DB) SchemaProvider {
+			return postgresSchema{db: db}
+		},
+	},
+	"sqlite": {
+		sqlDriver:       "sqlite3",
+		connectTemplate: "file:{{.Database}}?mode=ro",
+		newSchema: func(db *sql.DB) SchemaProvider {
+			return sqliteSchema{db: db}
+		},
+	},
+	"mysql": {
+		sqlDriver:       "mysql",
+		connectTemplate: "{{.UID}}:{{.PWD}}@tcp({{.Host}}:{{.Port}})/{{.Database}}",
+		newSchema: func(db *sql.DB) SchemaProvider {
+			return mysqlSchema{db: db}
+		},
+	},
+	"oracle": {
+		sqlDriver:       "oracle",
+		connectTemplate: "oracle://{{.UID}}:{{.PWD}}@{{.Host}}:{{.Port}}/{{.Database}}",
+		newSchema: func(db *sql.DB) SchemaProvider {
+			return oracleSchema{db: db}
+		},
+	},
+}
+
+func getDialect(name string) (dialect, error) {
+	d, ok := dialects[name]
+	if !ok {
+		var names []string
+		for name := range dialects {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return d, fmt.Errorf("unknown driver %q, expected one of %v", name, strings.Join(names, ", "))
+	}
+	return d, nil
+}
+
+type ConnectParam struct {
+	Host     string
+	Port     string
+	Database string
+	UID      string
+	PWD      string
+}
+
+func connectDB(d dialect, param ConnectParam) (*sql.DB, error) {
+	temp, err := template.New("con").Parse(d.connectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("template parse: %w", err)
+	}
+	conBuffer := &bytes.Buffer{}
+	err = temp.Execute(conBuffer, param)
+	if err != nil {
+		return nil, fmt.Errorf("template execute: %w", err)
+	}
+	db, err := sql.Open(d.sqlDriver, conBuffer.String())
+	if err != nil {
+		return nil, fmt.Errorf("sql open: %w", err)
+	}
+	return db, nil
+}
+
+// NewSchema returns the cached catalog of db, a database of driver: db2, postgres,
+// sqlite, mysql or oracle.
+func NewSchema(driver string, db *sql.DB) (SchemaProvider, error) {
+	d, err := getDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+	return NewCatalogSchema(d.newSchema(db)), nil
+}
+
+// Connect opens the database of driver described by param and returns its cached
+// catalog. The caller closes the returned database.
+func Connect(driver string, param ConnectParam) (SchemaProvider, *sql.DB, error) {
+	d, err := getDialect(driver)
+	if err != nil {
+		return nil, nil, err
+	}
+	db, err := connectDB(d, param)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connectDB %w", err)
+	}
+	return NewCatalogSchema(d.newSchema(db)), db, nil
+}
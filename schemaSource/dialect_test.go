@@ -0,0 +1,18 @@
+package schemaSource
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConnectWithoutDriver(t *testing.T) {
+	// the test binary registers no database/sql driver
+	_, _, err := Connect("sqlite", ConnectParam{Database: "test.db"})
+	if err == nil || !strings.Contains(err.Error(), `sql: unknown driver "sqlite3"`) {
+		t.Errorf("got error %v, want the unknown driver of database/sql", err)
+	}
+	_, _, err = Connect("sybase", ConnectParam{})
+	if err == nil || !strings.Contains(err.Error(), `unknown driver "sybase"`) {
+		t.Errorf("got error %v, want unknown driver sybase", err)
+	}
+}
@@ -0,0 +1,171 @@
+package schemaSource
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"tnd/work/generateJavaEntity/tableDefinition"
+)
+
+const snapshotVersion = 1
+
+// Snapshot is the offline copy of catalog metadata written by -dump and read by -from-snapshot.
+type Snapshot struct {
+	Version int
+	// Dialect names the database product the tables were read from; empty means db2.
+	Dialect string
+	Tables  []TableDef
+}
+
+// snapshotSchema serves table metadata from a Snapshot instead of a live database.
+type snapshotSchema struct {
+	dialect string
+	tables  []TableDef
+	index   map[TableIdentity]int
+}
+
+// NewSnapshotSchema returns the provider of the tables of snapshot.
+func NewSnapshotSchema(snapshot Snapshot) SchemaProvider {
+	result := snapshotSchema{
+		dialect: snapshot.Dialect,
+		tables:  snapshot.Tables,
+		index:   make(map[TableIdentity]int),
+	}
+	if result.dialect == "" {
+		result.dialect = "db2"
+	}
+	for i, table := range snapshot.Tables {
+		result.index[table.TableIdentity] = i
+	}
+	return result
+}
+
+func (s snapshotSchema) Dialect() string {
+	return s.dialect
+}
+
+func (s snapshotSchema) table(table TableIdentity) (TableDef, error) {
+	i, ok := s.index[table]
+	if !ok {
+		return TableDef{}, fmt.Errorf("table %v.%v not found in snapshot", table.Schema, table.Name)
+	}
+	return s.tables[i], nil
+}
+
+func (s snapshotSchema) ListTables(schema string) ([]TableIdentity, error) {
+	var result []TableIdentity
+	for _, table := range s.tables {
+		if table.Schema == schema {
+			result = append(result, table.TableIdentity)
+		}
+	}
+	return result, nil
+}
+
+func (s snapshotSchema) ListColumns(table TableIdentity) ([]ColumnDef, error) {
+	def, err := s.table(table)
+	return def.Columns, err
+}
+
+func (s snapshotSchema) ListPrimaryKeys(table TableIdentity) (map[string]bool, error) {
+	def, err := s.table(table)
+	return def.PrimaryKeys, err
+}
+
+func (s snapshotSchema) ListForeignKeysFrom(table TableIdentity) ([]ForeignKey, error) {
+	def, err := s.table(table)
+	return def.ForeignKeys, err
+}
+
+func (s snapshotSchema) ListForeignKeysTo(table TableIdentity) ([]ForeignKey, error) {
+	var result []ForeignKey
+	for _, def := range s.tables {
+		for _, fk := range def.ForeignKeys {
+			if fk.To == table {
+				result = append(result, fk)
+			}
+		}
+	}
+	return result, nil
+}
+
+// DumpSnapshot collects the definition of every table reachable from roots
+// through foreign keys in either direction.
+func DumpSnapshot(schema SchemaProvider, roots []TableIdentity) (Snapshot, error) {
+	snapshot := Snapshot{
+		Version: snapshotVersion,
+		Dialect: schema.Dialect(),
+	}
+	visited := make(map[TableIdentity]bool)
+	queue := append([]TableIdentity(nil), roots...)
+	for _, root := range roots {
+		visited[root] = true
+	}
+	for i := 0; i < len(queue); i++ {
+		tableDef, err := tableDefinition.GetTableDef(schema, queue[i])
+		if err != nil {
+			return snapshot, fmt.Errorf("get table def %v: %w", queue[i], err)
+		}
+		if len(tableDef.Columns) == 0 {
+			return snapshot, fmt.Errorf("table %v.%v has no columns", queue[i].Schema, queue[i].Name)
+		}
+		snapshot.Tables = append(snapshot.Tables, tableDef)
+		fks, err := schema.ListForeignKeysTo(queue[i])
+		if err != nil {
+			return snapshot, fmt.Errorf("list fk to table %v: %w", queue[i], err)
+		}
+		var related []TableIdentity
+		for _, fk := range tableDef.ForeignKeys {
+			related = append(related, fk.To)
+		}
+		for _, fk := range fks {
+			related = append(related, fk.From)
+		}
+		for _, table := range related {
+			if !visited[table] {
+				visited[table] = true
+				queue = append(queue, table)
+			}
+		}
+	}
+	sort.Slice(snapshot.Tables, func(i, j int) bool {
+		if snapshot.Tables[i].Schema != snapshot.Tables[j].Schema {
+			return snapshot.Tables[i].Schema < snapshot.Tables[j].Schema
+		}
+		return snapshot.Tables[i].Name < snapshot.Tables[j].Name
+	})
+	return snapshot, nil
+}
+
+// WriteSnapshot writes snapshot to fileName as indented JSON.
+func WriteSnapshot(fileName string, snapshot Snapshot) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("os create %v: %w", fileName, err)
+	}
+	defer file.Close()
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(snapshot); err != nil {
+		return fmt.Errorf("json encode: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot.
+func ReadSnapshot(fileName string) (Snapshot, error) {
+	var snapshot Snapshot
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return snapshot, fmt.Errorf("read file %v: %w", fileName, err)
+	}
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("json decode %v: %w", fileName, err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return snapshot, fmt.Errorf("snapshot %v has version %v, expected %v", fileName, snapshot.Version, snapshotVersion)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,271 @@
+package schemaSource
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"tnd/work/generateJavaEntity/tableDefinition"
+)
+
+type ColumnDef = tableDefinition.ColumnDef
+type ForeignKey = tableDefinition.ForeignKey
+type TableDef = tableDefinition.TableDef
+type TableIdentity = tableDefinition.TableIdentity
+type SchemaProvider = tableDefinition.SchemaProvider
+
+// db2Schema reads table metadata from the DB2 system catalog (syscat).
+type db2Schema struct {
+	db *sql.DB
+}
+
+func (s db2Schema) Dialect() string {
+	return "db2"
+}
+
+func (s db2Schema) ListTables(schema string) ([]TableIdentity, error) {
+	return listTables(s.db, schema)
+}
+
+func (s db2Schema) ListColumns(table TableIdentity) ([]ColumnDef, error) {
+	return listColumns(s.db, table)
+}
+
+func (s db2Schema) ListPrimaryKeys(table TableIdentity) (map[string]bool, error) {
+	return listPK(s.db, table)
+}
+
+func (s db2Schema) ListForeignKeysFrom(table TableIdentity) ([]ForeignKey, error) {
+	return listFkFromTable(s.db, table)
+}
+
+func (s db2Schema) ListForeignKeysTo(table TableIdentity) ([]ForeignKey, error) {
+	return ListFkToTable(s.db, table)
+}
+
+func listColumns(db *sql.DB, table TableIdentity) ([]ColumnDef, error) {
+	st, err := db.Prepare(`select colno, colname, typename, length, scale, nulls, "DEFAULT", identity, generated, remarks from syscat.columns where tabschema = ? and tabname = ? order by colno`)
+	if err != nil {
+		return nil, fmt.Errorf("db prepare: %w", err)
+	}
+	defer st.Close()
+	rs, err := st.Query(table.Schema, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("rs query: %w", err)
+	}
+	defer rs.Close()
+	var defs []ColumnDef
+	for rs.Next() {
+		def, err := scanDb2Column(rs)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// scanDb2Column reads a row of syscat.columns colno, colname, typename, length, scale,
+// nulls, default, identity, generated and remarks, preceded by the columns of dest.
+func scanDb2Column(rs *sql.Rows, dest ...interface{}) (ColumnDef, error) {
+	var def ColumnDef
+	var nulls, identity, generated string
+	var defaultValue, remarks sql.NullString
+	dest = append(dest, &def.Position, &def.Name, &def.Type, &def.Length, &def.Scale, &nulls, &defaultValue, &identity, &generated, &remarks)
+	if err := rs.Scan(dest...); err != nil {
+		return def, fmt.Errorf("rs scan: %w", err)
+	}
+	def.Nullable = nulls == "Y"
+	def.Default = defaultValue.String
+	def.Identity = identity == "Y"
+	// GENERATED is A or D for identity columns as well as for computed ones
+	def.Generated = !def.Identity && strings.TrimSpace(generated) != ""
+	def.Remarks = remarks.String
+	return def, nil
+}
+
+// LoadSchema reads every table of schema and the foreign keys referencing them
+// with one query each for columns, primary keys and references.
+func (s db2Schema) LoadSchema(schema string) ([]TableDef, []ForeignKey, error) {
+	tableIdentities, err := listTables(s.db, schema)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list tables: %w", err)
+	}
+	tables := make([]TableDef, len(tableIdentities))
+	index := make(map[string]int)
+	for i, table := range tableIdentities {
+		tables[i] = TableDef{TableIdentity: table, PrimaryKeys: make(map[string]bool)}
+		index[table.Name] = i
+	}
+	rs, err := s.db.Query(`select tabname, colno, colname, typename, length, scale, nulls, "DEFAULT", identity, generated, remarks
+		from syscat.columns where tabschema = ? order by tabname, colno`, schema)
+	if err != nil {
+		return nil, nil, fmt.Errorf("db query columns: %w", err)
+	}
+	defer rs.Close()
+	for rs.Next() {
+		var tabname string
+		def, err := scanDb2Column(rs, &tabname)
+		if err != nil {
+			return nil, nil, err
+		}
+		// views and aliases have columns too
+		if i, ok := index[strings.TrimSpace(tabname)]; ok {
+			tables[i].Columns = append(tables[i].Columns, def)
+		}
+	}
+	if err := rs.Err(); err != nil {
+		return nil, nil, err
+	}
+	rs, err = s.db.Query(`select key.tabname, key.colname from syscat.tabconst const, syscat.keycoluse key
+		where const.type = 'P' and const.tabschema = key.tabschema and const.tabname = key.tabname and const.constname = key.constname
+		and key.tabschema = ?`, schema)
+	if err != nil {
+		return nil, nil, fmt.Errorf("db query primary keys: %w", err)
+	}
+	defer rs.Close()
+	for rs.Next() {
+		var tabname, colname string
+		if err := rs.Scan(&tabname, &colname); err != nil {
+			return nil, nil, fmt.Errorf("rs scan: %w", err)
+		}
+		if i, ok := index[strings.TrimSpace(tabname)]; ok {
+			tables[i].PrimaryKeys[colname] = true
+		}
+	}
+	if err := rs.Err(); err != nil {
+		return nil, nil, err
+	}
+	fks, err := listFk(s.db, "tabschema = ? or reftabschema = ?", schema, schema)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list references: %w", err)
+	}
+	var referencing []ForeignKey
+	for _, fk := range fks {
+		if i, ok := index[fk.From.Name]; ok && fk.From.Schema == schema {
+			tables[i].ForeignKeys = append(tables[i].ForeignKeys, fk)
+		}
+		if fk.To.Schema == schema {
+			referencing = append(referencing, fk)
+		}
+	}
+	return tables, referencing, nil
+}
+
+func listTables(db *sql.DB, schema string) ([]TableIdentity, error) {
+	rs, err := db.Query(`select tabname from syscat.tables where tabschema = ? and type = 'T' order by tabname`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	return scanTableNames(rs, schema)
+}
+
+// scanTableNames reads the tables of schema from rows holding their names.
+func scanTableNames(rs *sql.Rows, schema string) ([]TableIdentity, error) {
+	var result []TableIdentity
+	for rs.Next() {
+		table := TableIdentity{Schema: schema}
+		if err := rs.Scan(&table.Name); err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		table.Name = strings.TrimSpace(table.Name)
+		result = append(result, table)
+	}
+	return result, rs.Err()
+}
+
+func listPK(db *sql.DB, table TableIdentity) (map[string]bool, error) {
+	st, err := db.Prepare(`select key.colname from syscat.tabconst const, syscat.keycoluse key where const.type='P' and const.constname=key.constname and key.tabschema=? AND key.TABNAME=?`)
+	if err != nil {
+		return nil, fmt.Errorf("db prepare: %w", err)
+	}
+	defer st.Close()
+	rs, err := st.Query(table.Schema, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("rs query: %w", err)
+	}
+	defer rs.Close()
+	pks := make(map[string]bool)
+	for rs.Next() {
+		var pk string
+		err := rs.Scan(&pk)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		pks[pk] = true
+	}
+	return pks, nil
+}
+
+func listFk(db *sql.DB, where string, argument ...interface{}) ([]ForeignKey, error) {
+	st, err := db.Prepare(`select constname, tabschema, tabname, reftabschema, reftabname, fk_colnames, pk_colnames, deleterule, updaterule from syscat.references where ` + where)
+	if err != nil {
+		return nil, fmt.Errorf("db prepare: %w", err)
+	}
+	defer st.Close()
+	rs, err := st.Query(argument...)
+	if err != nil {
+		return nil, fmt.Errorf("rs query: %w", err)
+	}
+	defer rs.Close()
+	var result []ForeignKey
+	for rs.Next() {
+		var fk ForeignKey
+		var fkColnames, pkColnames, deleteRule, updateRule string
+		err := rs.Scan(&fk.Constname, &fk.From.Schema, &fk.From.Name, &fk.To.Schema, &fk.To.Name, &fkColnames, &pkColnames, &deleteRule, &updateRule)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		// multi-column keys come as one blank padded list
+		fk.FkColumns = strings.Fields(fkColnames)
+		fk.PkColumns = strings.Fields(pkColnames)
+		fk.From.Schema = strings.TrimSpace(fk.From.Schema)
+		fk.To.Schema = strings.TrimSpace(fk.To.Schema)
+		fk.From.Name = strings.TrimSpace(fk.From.Name)
+		fk.To.Name = strings.TrimSpace(fk.To.Name)
+		fk.DeleteRule = db2ReferentialRules[deleteRule]
+		fk.UpdateRule = db2ReferentialRules[updateRule]
+		result = append(result, fk)
+	}
+	return result, nil
+}
+
+// db2ReferentialRules maps the codes of syscat.references DELETERULE and UPDATERULE.
+var db2ReferentialRules = map[string]string{
+	"A": "NO ACTION",
+	"C": "CASCADE",
+	"N": "SET NULL",
+	"R": "RESTRICT",
+}
+
+func listFkFromTable(db *sql.DB, table TableIdentity) ([]ForeignKey, error) {
+	return listFk(db, "tabschema = ? and tabname = ?", table.Schema, table.Name)
+}
+
+func ListFkToTable(db *sql.DB, table TableIdentity) ([]ForeignKey, error) {
+	return listFk(db, "reftabschema = ? and reftabname = ?", table.Schema, table.Name)
+}
+
+// scanForeignKeyColumns reads rows of constname, tabschema, tabname, reftabschema,
+// reftabname, fk column, pk column, delete rule and update rule ordered by constraint and column position,
+// merging the columns of a multi-column foreign key into one ForeignKey.
+func scanForeignKeyColumns(rs *sql.Rows) ([]ForeignKey, error) {
+	var result []ForeignKey
+	for rs.Next() {
+		var fk ForeignKey
+		var fkColumn, pkColumn string
+		err := rs.Scan(&fk.Constname, &fk.From.Schema, &fk.From.Name, &fk.To.Schema, &fk.To.Name, &fkColumn, &pkColumn, &fk.DeleteRule, &fk.UpdateRule)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		if n := len(result); n > 0 && result[n-1].Constname == fk.Constname && result[n-1].From == fk.From {
+			result[n-1].FkColumns = append(result[n-1].FkColumns, fkColumn)
+			result[n-1].PkColumns = append(result[n-1].PkColumns, pkColumn)
+			continue
+		}
+		fk.FkColumns = []string{fkColumn}
+		fk.PkColumns = []string{pkColumn}
+		result = append(result, fk)
+	}
+	return result, rs.Err()
+}
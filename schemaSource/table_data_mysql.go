@@ -0,0 +1,104 @@
+package schemaSource
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// mysqlSchema reads table metadata from MySQL/MariaDB information_schema.
+// The schema of a table is its MySQL database.
+type mysqlSchema struct {
+	db *sql.DB
+}
+
+func (s mysqlSchema) Dialect() string {
+	return "mysql"
+}
+
+func (s mysqlSchema) ListTables(schema string) ([]TableIdentity, error) {
+	rs, err := s.db.Query(`select table_name from information_schema.tables where table_schema = ? and table_type = 'BASE TABLE' order by table_name`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	return scanTableNames(rs, schema)
+}
+
+func (s mysqlSchema) ListColumns(table TableIdentity) ([]ColumnDef, error) {
+	rs, err := s.db.Query(`select ordinal_position - 1, column_name, upper(data_type), column_type,
+		coalesce(character_maximum_length, numeric_precision, 0), coalesce(numeric_scale, datetime_precision, 0),
+		is_nullable = 'YES', coalesce(column_default, ''), extra, column_comment
+		from information_schema.columns where table_schema = ? and table_name = ? order by ordinal_position`, table.Schema, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	var defs []ColumnDef
+	for rs.Next() {
+		var def ColumnDef
+		var columnType, extra string
+		err := rs.Scan(&def.Position, &def.Name, &def.Type, &columnType, &def.Length, &def.Scale, &def.Nullable, &def.Default, &extra, &def.Remarks)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		if strings.HasPrefix(columnType, "tinyint(1)") {
+			// the display width is the only thing telling a boolean apart from a small number
+			def.Length = 1
+		}
+		def.Identity, def.Generated = parseMysqlExtra(extra)
+		defs = append(defs, def)
+	}
+	return defs, rs.Err()
+}
+
+// parseMysqlExtra reports whether the extra column of information_schema.columns
+// marks an identity column and a generated one. MySQL 8 also sets DEFAULT_GENERATED
+// for an expression default such as CURRENT_TIMESTAMP, an ordinary column.
+func parseMysqlExtra(extra string) (identity bool, generated bool) {
+	extra = strings.ToUpper(extra)
+	identity = strings.Contains(extra, "AUTO_INCREMENT")
+	generated = strings.Contains(extra, "VIRTUAL GENERATED") || strings.Contains(extra, "STORED GENERATED")
+	return identity, generated
+}
+
+func (s mysqlSchema) ListPrimaryKeys(table TableIdentity) (map[string]bool, error) {
+	rs, err := s.db.Query(`select column_name from information_schema.key_column_usage
+		where constraint_name = 'PRIMARY' and table_schema = ? and table_name = ?`, table.Schema, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	pks := make(map[string]bool)
+	for rs.Next() {
+		var pk string
+		err := rs.Scan(&pk)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		pks[pk] = true
+	}
+	return pks, rs.Err()
+}
+
+func (s mysqlSchema) listFk(where string, argument ...interface{}) ([]ForeignKey, error) {
+	rs, err := s.db.Query(`select k.constraint_name, k.table_schema, k.table_name, k.referenced_table_schema, k.referenced_table_name,
+		k.column_name, k.referenced_column_name, r.delete_rule, r.update_rule
+		from information_schema.key_column_usage k
+		join information_schema.referential_constraints r on r.constraint_schema = k.constraint_schema and r.constraint_name = k.constraint_name
+		where k.referenced_table_name is not null and `+where+`
+		order by k.table_schema, k.table_name, k.constraint_name, k.ordinal_position`, argument...)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	return scanForeignKeyColumns(rs)
+}
+
+func (s mysqlSchema) ListForeignKeysFrom(table TableIdentity) ([]ForeignKey, error) {
+	return s.listFk("k.table_schema = ? and k.table_name = ?", table.Schema, table.Name)
+}
+
+func (s mysqlSchema) ListForeignKeysTo(table TableIdentity) ([]ForeignKey, error) {
+	return s.listFk("k.referenced_table_schema = ? and k.referenced_table_name = ?", table.Schema, table.Name)
+}
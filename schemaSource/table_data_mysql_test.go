@@ -0,0 +1,25 @@
+package schemaSource
+
+import "testing"
+
+func TestParseMysqlExtra(t *testing.T) {
+	tests := []struct {
+		extra     string
+		identity  bool
+		generated bool
+	}{
+		{"", false, false},
+		{"auto_increment", true, false},
+		{"VIRTUAL GENERATED", false, true},
+		{"STORED GENERATED", false, true},
+		{"DEFAULT_GENERATED", false, false},
+		{"DEFAULT_GENERATED on update CURRENT_TIMESTAMP", false, false},
+		{"on update CURRENT_TIMESTAMP", false, false},
+	}
+	for _, test := range tests {
+		identity, generated := parseMysqlExtra(test.extra)
+		if identity != test.identity || generated != test.generated {
+			t.Errorf("%q: identity %v generated %v, want %v %v", test.extra, identity, generated, test.identity, test.generated)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package schemaSource
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// oracleSchema reads table metadata from the Oracle ALL_* dictionary views.
+// The schema of a table is its owner.
+type oracleSchema struct {
+	db *sql.DB
+}
+
+func (s oracleSchema) Dialect() string {
+	return "oracle"
+}
+
+func (s oracleSchema) ListTables(schema string) ([]TableIdentity, error) {
+	rs, err := s.db.Query(`select table_name from all_tables where owner = :1 order by table_name`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	return scanTableNames(rs, schema)
+}
+
+func (s oracleSchema) ListColumns(table TableIdentity) ([]ColumnDef, error) {
+	rs, err := s.db.Query(`select c.column_id - 1, c.column_name, c.data_type,
+		case when c.data_type in ('NUMBER', 'FLOAT') then nvl(c.data_precision, 0) when c.char_length > 0 then c.char_length else c.data_length end,
+		nvl(c.data_scale, 0), c.nullable, c.data_default, c.identity_column, cc.comments
+		from all_tab_columns c
+		left join all_col_comments cc on cc.owner = c.owner and cc.table_name = c.table_name and cc.column_name = c.column_name
+		where c.owner = :1 and c.table_name = :2 order by c.column_id`, table.Schema, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	var defs []ColumnDef
+	for rs.Next() {
+		var def ColumnDef
+		var nullable, identity string
+		var defaultValue, remarks sql.NullString
+		err := rs.Scan(&def.Position, &def.Name, &def.Type, &def.Length, &def.Scale, &nullable, &defaultValue, &identity, &remarks)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		def.Nullable = nullable == "Y"
+		def.Default = strings.TrimSpace(defaultValue.String)
+		def.Identity = identity == "YES"
+		def.Remarks = remarks.String
+		defs = append(defs, def)
+	}
+	return defs, rs.Err()
+}
+
+func (s oracleSchema) ListPrimaryKeys(table TableIdentity) (map[string]bool, error) {
+	rs, err := s.db.Query(`select cc.column_name from all_constraints c
+		join all_cons_columns cc on cc.owner = c.owner and cc.constraint_name = c.constraint_name
+		where c.constraint_type = 'P' and c.owner = :1 and c.table_name = :2`, table.Schema, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	pks := make(map[string]bool)
+	for rs.Next() {
+		var pk string
+		err := rs.Scan(&pk)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		pks[pk] = true
+	}
+	return pks, rs.Err()
+}
+
+func (s oracleSchema) listFk(where string, argument ...interface{}) ([]ForeignKey, error) {
+	// Oracle has no ON UPDATE clause, updates of referenced keys are always rejected
+	rs, err := s.db.Query(`select c.constraint_name, c.owner, c.table_name, r.owner, r.table_name, fc.column_name, rc.column_name,
+		c.delete_rule, 'NO ACTION'
+		from all_constraints c
+		join all_constraints r on r.owner = c.r_owner and r.constraint_name = c.r_constraint_name
+		join all_cons_columns fc on fc.owner = c.owner and fc.constraint_name = c.constraint_name
+		join all_cons_columns rc on rc.owner = r.owner and rc.constraint_name = r.constraint_name and rc.position = fc.position
+		where c.constraint_type = 'R' and `+where+`
+		order by c.owner, c.table_name, c.constraint_name, fc.position`, argument...)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	return scanForeignKeyColumns(rs)
+}
+
+func (s oracleSchema) ListForeignKeysFrom(table TableIdentity) ([]ForeignKey, error) {
+	return s.listFk("c.owner = :1 and c.table_name = :2", table.Schema, table.Name)
+}
+
+func (s oracleSchema) ListForeignKeysTo(table TableIdentity) ([]ForeignKey, error) {
+	return s.listFk("r.owner = :1 and r.table_name = :2", table.Schema, table.Name)
+}
@@ -0,0 +1,123 @@
+package schemaSource
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// postgresSchema reads table metadata from PostgreSQL information_schema and pg_catalog.
+type postgresSchema struct {
+	db *sql.DB
+}
+
+func (s postgresSchema) Dialect() string {
+	return "postgres"
+}
+
+func (s postgresSchema) ListTables(schema string) ([]TableIdentity, error) {
+	rs, err := s.db.Query(`select table_name from information_schema.tables where table_schema = $1 and table_type = 'BASE TABLE' order by table_name`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	return scanTableNames(rs, schema)
+}
+
+func (s postgresSchema) ListColumns(table TableIdentity) ([]ColumnDef, error) {
+	rs, err := s.db.Query(`select ordinal_position - 1, column_name, udt_name,
+		coalesce(character_maximum_length, numeric_precision, 0), coalesce(numeric_scale, datetime_precision, 0),
+		is_nullable = 'YES', coalesce(column_default, ''), is_identity = 'YES' or coalesce(column_default, '') like 'nextval(%',
+		is_generated = 'ALWAYS',
+		coalesce(col_description(format('%I.%I', table_schema, table_name)::regclass, ordinal_position), '')
+		from information_schema.columns where table_schema = $1 and table_name = $2 order by ordinal_position`, table.Schema, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	var defs []ColumnDef
+	for rs.Next() {
+		var def ColumnDef
+		err := rs.Scan(&def.Position, &def.Name, &def.Type, &def.Length, &def.Scale, &def.Nullable, &def.Default, &def.Identity, &def.Generated, &def.Remarks)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		if def.Identity && strings.HasPrefix(def.Default, "nextval(") {
+			// serial columns: the sequence is an implementation detail of the identity
+			def.Default = ""
+		}
+		defs = append(defs, def)
+	}
+	return defs, rs.Err()
+}
+
+func (s postgresSchema) ListPrimaryKeys(table TableIdentity) (map[string]bool, error) {
+	rs, err := s.db.Query(`select kcu.column_name from information_schema.table_constraints tc
+		join information_schema.key_column_usage kcu on kcu.constraint_schema = tc.constraint_schema and kcu.constraint_name = tc.constraint_name
+		where tc.constraint_type = 'PRIMARY KEY' and tc.table_schema = $1 and tc.table_name = $2`, table.Schema, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	pks := make(map[string]bool)
+	for rs.Next() {
+		var pk string
+		err := rs.Scan(&pk)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		pks[pk] = true
+	}
+	return pks, rs.Err()
+}
+
+func (s postgresSchema) listFk(where string, argument ...interface{}) ([]ForeignKey, error) {
+	rs, err := s.db.Query(`select con.conname, fns.nspname, fcl.relname, tns.nspname, tcl.relname,
+		array(select a.attname::text from unnest(con.conkey) with ordinality k(attnum, n)
+			join pg_attribute a on a.attrelid = con.conrelid and a.attnum = k.attnum order by k.n),
+		array(select a.attname::text from unnest(con.confkey) with ordinality k(attnum, n)
+			join pg_attribute a on a.attrelid = con.confrelid and a.attnum = k.attnum order by k.n),
+		con.confdeltype, con.confupdtype
+		from pg_constraint con
+		join pg_class fcl on fcl.oid = con.conrelid
+		join pg_namespace fns on fns.oid = fcl.relnamespace
+		join pg_class tcl on tcl.oid = con.confrelid
+		join pg_namespace tns on tns.oid = tcl.relnamespace
+		where con.contype = 'f' and `+where, argument...)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	var result []ForeignKey
+	for rs.Next() {
+		var fk ForeignKey
+		var deleteRule, updateRule string
+		err := rs.Scan(&fk.Constname, &fk.From.Schema, &fk.From.Name, &fk.To.Schema, &fk.To.Name, pq.Array(&fk.FkColumns), pq.Array(&fk.PkColumns), &deleteRule, &updateRule)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		fk.DeleteRule = postgresReferentialRules[deleteRule]
+		fk.UpdateRule = postgresReferentialRules[updateRule]
+		result = append(result, fk)
+	}
+	return result, rs.Err()
+}
+
+// postgresReferentialRules maps the codes of pg_constraint confdeltype and confupdtype.
+var postgresReferentialRules = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
+
+func (s postgresSchema) ListForeignKeysFrom(table TableIdentity) ([]ForeignKey, error) {
+	return s.listFk("fns.nspname = $1 and fcl.relname = $2", table.Schema, table.Name)
+}
+
+func (s postgresSchema) ListForeignKeysTo(table TableIdentity) ([]ForeignKey, error) {
+	return s.listFk("tns.nspname = $1 and tcl.relname = $2", table.Schema, table.Name)
+}
@@ -0,0 +1,158 @@
+package schemaSource
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sqliteSchema reads table metadata from a SQLite database with the table_info and
+// foreign_key_list pragmas. SQLite has no schemas, so the schema of a requested
+// table is ignored for lookups and carried over to the tables it references.
+type sqliteSchema struct {
+	db *sql.DB
+}
+
+func (s sqliteSchema) Dialect() string {
+	return "sqlite"
+}
+
+func (s sqliteSchema) ListTables(schema string) ([]TableIdentity, error) {
+	rs, err := s.db.Query(`select name from sqlite_master where type = 'table' and name not like 'sqlite_%' order by name`)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	return scanTableNames(rs, schema)
+}
+
+func (s sqliteSchema) ListColumns(table TableIdentity) ([]ColumnDef, error) {
+	rs, err := s.db.Query(`select cid, name, type, "notnull" = 0 and pk = 0, coalesce(dflt_value, ''),
+		upper(type) = 'INTEGER' and pk = 1 and (select count(*) from pragma_table_info(?) where pk > 0) = 1
+		from pragma_table_info(?) order by cid`, table.Name, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	var defs []ColumnDef
+	for rs.Next() {
+		var def ColumnDef
+		var declaredType string
+		// an INTEGER PRIMARY KEY is an alias of the rowid, which SQLite assigns itself
+		err := rs.Scan(&def.Position, &def.Name, &declaredType, &def.Nullable, &def.Default, &def.Identity)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		def.Type, def.Length, def.Scale = parseSqliteType(declaredType)
+		defs = append(defs, def)
+	}
+	return defs, rs.Err()
+}
+
+// parseSqliteType splits a declared type such as DECIMAL(15,2) into name, length and scale.
+func parseSqliteType(declaredType string) (string, int, int) {
+	typeName := strings.ToUpper(strings.TrimSpace(declaredType))
+	var length, scale int
+	if i := strings.Index(typeName, "("); i >= 0 {
+		args := strings.Split(strings.TrimSuffix(typeName[i+1:], ")"), ",")
+		length, _ = strconv.Atoi(strings.TrimSpace(args[0]))
+		if len(args) > 1 {
+			scale, _ = strconv.Atoi(strings.TrimSpace(args[1]))
+		}
+		typeName = strings.TrimSpace(typeName[:i])
+	}
+	return typeName, length, scale
+}
+
+func (s sqliteSchema) ListPrimaryKeys(table TableIdentity) (map[string]bool, error) {
+	rs, err := s.db.Query(`select name from pragma_table_info(?) where pk > 0`, table.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	pks := make(map[string]bool)
+	for rs.Next() {
+		var pk string
+		err := rs.Scan(&pk)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		pks[pk] = true
+	}
+	return pks, rs.Err()
+}
+
+func (s sqliteSchema) listFk(schema string, where string, argument ...interface{}) ([]ForeignKey, error) {
+	rs, err := s.db.Query(`select m.name, f.id, f."table", f."from", f."to", f.on_delete, f.on_update
+		from sqlite_master m, pragma_foreign_key_list(m.name) f
+		where m.type = 'table' and `+where+` order by m.name, f.id, f.seq`, argument...)
+	if err != nil {
+		return nil, fmt.Errorf("db query: %w", err)
+	}
+	defer rs.Close()
+	var result []ForeignKey
+	for rs.Next() {
+		var fromTable, toTable, fromColumn, deleteRule, updateRule string
+		var toColumn sql.NullString
+		var id int
+		err := rs.Scan(&fromTable, &id, &toTable, &fromColumn, &toColumn, &deleteRule, &updateRule)
+		if err != nil {
+			return nil, fmt.Errorf("rs scan: %w", err)
+		}
+		constname := fmt.Sprintf("FK_%v_%v", fromTable, id)
+		if n := len(result); n > 0 && result[n-1].Constname == constname {
+			// next column of a multi-column foreign key
+			result[n-1].FkColumns = append(result[n-1].FkColumns, fromColumn)
+			if toColumn.Valid {
+				result[n-1].PkColumns = append(result[n-1].PkColumns, toColumn.String)
+			}
+			continue
+		}
+		fk := ForeignKey{
+			Constname:  constname,
+			From:       TableIdentity{Schema: schema, Name: fromTable},
+			To:         TableIdentity{Schema: schema, Name: toTable},
+			FkColumns:  []string{fromColumn},
+			DeleteRule: deleteRule,
+			UpdateRule: updateRule,
+		}
+		if toColumn.Valid {
+			fk.PkColumns = []string{toColumn.String}
+		}
+		result = append(result, fk)
+	}
+	if err := rs.Err(); err != nil {
+		return nil, err
+	}
+	// REFERENCES without a column list points at the primary key of the referenced table
+	for i, fk := range result {
+		if len(fk.PkColumns) > 0 {
+			continue
+		}
+		columns, err := s.ListColumns(fk.To)
+		if err != nil {
+			return nil, fmt.Errorf("list columns %v: %w", fk.To.Name, err)
+		}
+		pks, err := s.ListPrimaryKeys(fk.To)
+		if err != nil {
+			return nil, fmt.Errorf("list pk %v: %w", fk.To.Name, err)
+		}
+		var pkColumns []string
+		for _, col := range columns {
+			if pks[col.Name] {
+				pkColumns = append(pkColumns, col.Name)
+			}
+		}
+		result[i].PkColumns = pkColumns
+	}
+	return result, nil
+}
+
+func (s sqliteSchema) ListForeignKeysFrom(table TableIdentity) ([]ForeignKey, error) {
+	return s.listFk(table.Schema, "m.name = ? collate nocase", table.Name)
+}
+
+func (s sqliteSchema) ListForeignKeysTo(table TableIdentity) ([]ForeignKey, error) {
+	return s.listFk(table.Schema, `f."table" = ? collate nocase`, table.Name)
+}
@@ -0,0 +1,22 @@
+package tableDefinition
+
+import (
+	"fmt"
+)
+
+// GetTableDef reads the columns, primary key and foreign keys of table.
+func GetTableDef(schema SchemaProvider, table TableIdentity) (TableDef, error) {
+	var tableDef TableDef
+	var err error
+	tableDef.TableIdentity = table
+	if tableDef.Columns, err = schema.ListColumns(table); err != nil {
+		return tableDef, fmt.Errorf("list column error: %w", err)
+	}
+	if tableDef.PrimaryKeys, err = schema.ListPrimaryKeys(table); err != nil {
+		return tableDef, fmt.Errorf("list pk error: %w", err)
+	}
+	if tableDef.ForeignKeys, err = schema.ListForeignKeysFrom(table); err != nil {
+		return tableDef, fmt.Errorf("list pk error: %w", err)
+	}
+	return tableDef, nil
+}
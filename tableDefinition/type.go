@@ -1,19 +1,27 @@
 package tableDefinition
 
 type ColumnDef struct {
-	Position int
-	Name     string
-	Type     string
-	Length   int
-	Scale    int
+	Position  int
+	Name      string
+	Type      string
+	Length    int
+	Scale     int
+	Nullable  bool
+	Default   string
+	Identity  bool
+	Generated bool
+	Remarks   string
 }
 
 type ForeignKey struct {
-	Constname  string
-	From       TableIdentity
-	To         TableIdentity
-	FkColnames string
-	PkColnames string
+	Constname string
+	From      TableIdentity
+	To        TableIdentity
+	FkColumns []string
+	PkColumns []string
+	// DeleteRule and UpdateRule are CASCADE, RESTRICT, NO ACTION, SET NULL or SET DEFAULT.
+	DeleteRule string
+	UpdateRule string
 }
 
 type TableIdentity struct {
@@ -27,3 +35,12 @@ type TableDef struct {
 	ForeignKeys []ForeignKey
 	PrimaryKeys map[string]bool
 }
+
+type SchemaProvider interface {
+	Dialect() string
+	ListTables(schema string) ([]TableIdentity, error)
+	ListColumns(table TableIdentity) ([]ColumnDef, error)
+	ListPrimaryKeys(table TableIdentity) (map[string]bool, error)
+	ListForeignKeysFrom(table TableIdentity) ([]ForeignKey, error)
+	ListForeignKeysTo(table TableIdentity) ([]ForeignKey, error)
+}
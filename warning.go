@@ -0,0 +1,16 @@
+package main
+
+import (
+	"log"
+)
+
+// printWarnings reports together the problems that did not stop the generation.
+func printWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	log.Printf("%v warning(s):", len(warnings))
+	for _, warning := range warnings {
+		log.Println(" ", warning)
+	}
+}